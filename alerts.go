@@ -0,0 +1,217 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Redash alert operators, see AlertOptions.Op.
+const (
+	AlertGreater      = ">"
+	AlertGreaterEqual = ">="
+	AlertLess         = "<"
+	AlertLessEqual    = "<="
+	AlertEqual        = "=="
+	AlertNotEqual     = "!="
+)
+
+// Redash alert states, see Alert.State.
+const (
+	AlertUnknown   = "unknown"
+	AlertTriggered = "triggered"
+	AlertOk        = "ok"
+)
+
+// Default Alerts
+var Alerts = &AlertsS{DefaultClient}
+
+// interface of make Alerts endpoint.
+type Alertser interface {
+	Alerts(string) string
+}
+
+// Default struct for alerts.
+type AlertsS struct {
+	Client Interface
+}
+
+// Default implement of Alerts.
+func (as AlertsS) Alerts(s string) (rs string) {
+	return "/api/alerts/" + s
+}
+
+// Wrap Redash alert options, condition is
+// "value of Column in first row" Op Value.
+type AlertOptions struct {
+	Column string `json:"column"`
+	Op     string `json:"op"`
+	// Value is number or string to compare with.
+	Value         interface{} `json:"value"`
+	CustomSubject string      `json:"custom_subject,omitempty"`
+	CustomBody    string      `json:"custom_body,omitempty"`
+	Muted         bool        `json:"muted,omitempty"`
+}
+
+// Wrap Redash alert.
+type Alert struct {
+	Id              int           `json:"id"`
+	Name            string        `json:"name"`
+	Query           ResponseQuery `json:"query"`
+	Options         AlertOptions  `json:"options"`
+	State           string        `json:"state"`
+	LastTriggeredAt string        `json:"last_triggered_at"`
+	// Rearm is seconds to wait before notifying again while triggered,
+	// nil(or 0) means notify only once.
+	Rearm     *int   `json:"rearm"`
+	UpdatedAt string `json:"updated_at"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Wrap Redash new alert. It is also used to update, then zero(or nil)
+// fields are not updated.
+type NewAlert struct {
+	Name    string        `json:"name,omitempty"`
+	QueryId int           `json:"query_id,omitempty"`
+	Options *AlertOptions `json:"options,omitempty"`
+	Rearm   *int          `json:"rearm,omitempty"`
+}
+
+// Wrap Redash alert subscription.
+type AlertSubscription struct {
+	Id      int `json:"id"`
+	AlertId int `json:"alert_id"`
+	// Destination is nil for email to the user.
+	Destination *Destination `json:"destination"`
+}
+
+// Wrap Redash api GET alerts.
+func (as AlertsS) GetAlerts() (alerts []Alert, err error) {
+	return as.GetAlertsContext(context.Background())
+}
+
+// GetAlertsContext is GetAlerts with context.
+func (as AlertsS) GetAlertsContext(ctx context.Context) (alerts []Alert, err error) {
+	if err = doJSON(ctx, as.Client, http.MethodGet, as.Alerts(""), nil, nil, &alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// Wrap Redash api GET alerts/${alert id}.
+func (as AlertsS) GetAlert(alertId int) (a *Alert, err error) {
+	return as.GetAlertContext(context.Background(), alertId)
+}
+
+// GetAlertContext is GetAlert with context.
+func (as AlertsS) GetAlertContext(ctx context.Context, alertId int) (a *Alert, err error) {
+	a = &Alert{}
+	if err = doJSON(ctx, as.Client, http.MethodGet, as.Alerts(strconv.Itoa(alertId)), nil, nil, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Wrap Redash api POST alerts.
+func (as AlertsS) PostAlert(newAlert NewAlert) (a *Alert, err error) {
+	return as.PostAlertContext(context.Background(), newAlert)
+}
+
+// PostAlertContext is PostAlert with context.
+func (as AlertsS) PostAlertContext(ctx context.Context, newAlert NewAlert) (a *Alert, err error) {
+	a = &Alert{}
+	if err = doJSON(ctx, as.Client, http.MethodPost, as.Alerts(""), nil, newAlert, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Wrap Redash api POST alerts/${alert id}.
+func (as AlertsS) PostAlertId(alertId int, update NewAlert) (a *Alert, err error) {
+	return as.PostAlertIdContext(context.Background(), alertId, update)
+}
+
+// PostAlertIdContext is PostAlertId with context.
+func (as AlertsS) PostAlertIdContext(ctx context.Context, alertId int, update NewAlert) (a *Alert, err error) {
+	a = &Alert{}
+	if err = doJSON(ctx, as.Client, http.MethodPost, as.Alerts(strconv.Itoa(alertId)), nil, update, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Wrap Redash api DELETE alerts/${alert id}.
+func (as AlertsS) DeleteAlert(alertId int) (err error) {
+	return as.DeleteAlertContext(context.Background(), alertId)
+}
+
+// DeleteAlertContext is DeleteAlert with context.
+func (as AlertsS) DeleteAlertContext(ctx context.Context, alertId int) (err error) {
+	return doJSON(ctx, as.Client, http.MethodDelete, as.Alerts(strconv.Itoa(alertId)), nil, nil, nil)
+}
+
+// Wrap Redash api POST mute, which stops notifications of alert.
+func (as AlertsS) PostMute(alertId int) (err error) {
+	return as.PostMuteContext(context.Background(), alertId)
+}
+
+// PostMuteContext is PostMute with context.
+func (as AlertsS) PostMuteContext(ctx context.Context, alertId int) (err error) {
+	return doJSON(ctx, as.Client, http.MethodPost, as.Alerts(fmt.Sprintf("%d/mute", alertId)), nil, nil, nil)
+}
+
+// Wrap Redash api DELETE mute, which unmutes alert.
+func (as AlertsS) DeleteMute(alertId int) (err error) {
+	return as.DeleteMuteContext(context.Background(), alertId)
+}
+
+// DeleteMuteContext is DeleteMute with context.
+func (as AlertsS) DeleteMuteContext(ctx context.Context, alertId int) (err error) {
+	return doJSON(ctx, as.Client, http.MethodDelete, as.Alerts(fmt.Sprintf("%d/mute", alertId)), nil, nil, nil)
+}
+
+// Wrap Redash api GET subscriptions.
+func (as AlertsS) GetSubscriptions(alertId int) (subscriptions []AlertSubscription, err error) {
+	return as.GetSubscriptionsContext(context.Background(), alertId)
+}
+
+// GetSubscriptionsContext is GetSubscriptions with context.
+func (as AlertsS) GetSubscriptionsContext(ctx context.Context, alertId int) (subscriptions []AlertSubscription, err error) {
+	if err = doJSON(ctx, as.Client, http.MethodGet, as.Alerts(fmt.Sprintf("%d/subscriptions", alertId)), nil, nil, &subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// Wrap Redash api POST subscriptions. destinationId 0 means email to
+// the user of apikey.
+func (as AlertsS) PostSubscription(alertId, destinationId int) (subscription *AlertSubscription, err error) {
+	return as.PostSubscriptionContext(context.Background(), alertId, destinationId)
+}
+
+// PostSubscriptionContext is PostSubscription with context.
+func (as AlertsS) PostSubscriptionContext(ctx context.Context, alertId, destinationId int) (subscription *AlertSubscription, err error) {
+	body := struct {
+		DestinationId int `json:"destination_id,omitempty"`
+	}{destinationId}
+	subscription = &AlertSubscription{}
+	if err = doJSON(ctx, as.Client, http.MethodPost, as.Alerts(fmt.Sprintf("%d/subscriptions", alertId)), nil, body, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// Wrap Redash api DELETE subscriptions/${subscription id}.
+func (as AlertsS) DeleteSubscription(alertId, subscriptionId int) (err error) {
+	return as.DeleteSubscriptionContext(context.Background(), alertId, subscriptionId)
+}
+
+// DeleteSubscriptionContext is DeleteSubscription with context.
+func (as AlertsS) DeleteSubscriptionContext(ctx context.Context, alertId, subscriptionId int) (err error) {
+	return doJSON(ctx, as.Client, http.MethodDelete, as.Alerts(fmt.Sprintf("%d/subscriptions/%d", alertId, subscriptionId)), nil, nil, nil)
+}
@@ -0,0 +1,108 @@
+package redash
+
+import (
+	"fmt"
+	"testing"
+)
+
+var alertResp = fmt.Sprintf(`{
+  "id": 1,
+  "name": "too many",
+  "query": %s,
+  "options": {"column": "count", "op": ">", "value": 100, "custom_subject": "alert"},
+  "state": "triggered",
+  "last_triggered_at": "2017-07-16T10:52:26.541613+00:00",
+  "rearm": 3600,
+  "updated_at": "2017-07-16T10:52:26.541613+00:00",
+  "created_at": "2017-07-16T10:52:26.541613+00:00"
+}`, scheduledQueryResp)
+
+const subscriptionResp = `{"id": 5, "alert_id": 1, "destination": {"id": 2, "name": "slack", "type": "slack"}}`
+
+func TestAlerts(t *testing.T) {
+
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"GET /api/alerts":                      {body: "[" + alertResp + "]"},
+		"POST /api/alerts":                     {body: alertResp},
+		"GET /api/alerts/1":                    {body: alertResp},
+		"POST /api/alerts/1":                   {body: alertResp},
+		"DELETE /api/alerts/1":                 {body: `null`},
+		"POST /api/alerts/1/mute":              {body: `null`},
+		"DELETE /api/alerts/1/mute":            {body: `null`},
+		"GET /api/alerts/1/subscriptions":      {body: "[" + subscriptionResp + "]"},
+		"POST /api/alerts/1/subscriptions":     {body: subscriptionResp},
+		"DELETE /api/alerts/1/subscriptions/5": {body: `null`},
+	})
+	as := AlertsS{client}
+
+	alerts, err := as.GetAlerts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := alerts[0]
+	if a.State != AlertTriggered || a.Query.Name != "helloQuery" || a.Query.Schedule.Interval != 3600 || *a.Rearm != 3600 {
+		t.Fatalf("Alert is bad, have: %+v", a)
+	}
+	if a.Options.Op != AlertGreater || a.Options.Value != float64(100) || a.Options.CustomSubject != "alert" {
+		t.Fatalf("AlertOptions is bad, have: %+v", a.Options)
+	}
+
+	if _, err = as.GetAlert(1); err != nil {
+		t.Fatal(err)
+	}
+
+	rearm := 600
+	newAlert := NewAlert{
+		Name:    "too many",
+		QueryId: 1,
+		Options: &AlertOptions{Column: "count", Op: AlertGreaterEqual, Value: 100},
+		Rearm:   &rearm,
+	}
+	if _, err = as.PostAlert(newAlert); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"too many","query_id":1,"options":{"column":"count","op":">=","value":100},"rearm":600}`; bodies["POST /api/alerts"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/alerts"])
+	}
+	if _, err = as.PostAlertId(1, NewAlert{Options: &AlertOptions{Column: "count", Op: AlertEqual, Value: "x", Muted: true}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"options":{"column":"count","op":"==","value":"x","muted":true}}`; bodies["POST /api/alerts/1"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/alerts/1"])
+	}
+
+	// name only update keeps condition of alert.
+	if _, err = as.PostAlertId(1, NewAlert{Name: "renamed"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"renamed"}`; bodies["POST /api/alerts/1"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/alerts/1"])
+	}
+
+	if err = as.PostMute(1); err != nil {
+		t.Fatal(err)
+	}
+	if err = as.DeleteMute(1); err != nil {
+		t.Fatal(err)
+	}
+
+	subscriptions, err := as.GetSubscriptions(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subscriptions) != 1 || subscriptions[0].Id != 5 || subscriptions[0].Destination.Type != DestinationSlack {
+		t.Fatalf("Subscriptions is bad, have: %+v", subscriptions)
+	}
+	if _, err = as.PostSubscription(1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"destination_id":2}`; bodies["POST /api/alerts/1/subscriptions"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/alerts/1/subscriptions"])
+	}
+	if err = as.DeleteSubscription(1, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err = as.DeleteAlert(1); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -19,12 +19,20 @@
      GetInter/PostInter/DeleteInter with new Client.
    Case 3:
      Queries.GetQuery/(other func in Queries)
+   Case 4:
+     Queries.GetQueryTyped/(other *Typed func in Queries) to get
+     decoded structs instead of io.Reader.
+
+   Functions and methods calling api have XxxContext variant which
+   accepts context.Context as first argument.
 
 */
 package redash
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -34,6 +42,7 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"time"
 )
 
 const (
@@ -64,8 +73,12 @@ func defaultOpts() *Options {
 // Options is option value container.
 type Options struct {
 	Params map[string]string
+	// Values is multi-valued params like tags, added with Params.
+	Values url.Values
 	Header map[string]string
 	Body   io.Reader
+	// Idempotent marks request safe to retry even if method is POST.
+	Idempotent bool
 }
 
 // Url is Redash server's endpoint.
@@ -101,58 +114,157 @@ type Interface interface {
 
 // GetInter do Redash GET with Interface and return result.
 func GetInter(client Interface, sub string, params map[string]string) (resp *http.Response, err error) {
+	return GetInterContext(context.Background(), client, sub, params)
+}
+
+// GetInterContext is GetInter with context.
+func GetInterContext(ctx context.Context, client Interface, sub string, params map[string]string) (resp *http.Response, err error) {
 	opts := client.DefaultOpts()
 	for key, value := range params {
 		opts.Params[key] = value
 	}
-	return DoInter(client, http.MethodGet, sub, opts)
+	return DoInterContext(ctx, client, http.MethodGet, sub, opts)
 }
 
 // PostInter do Redash POST with Interface and return result.
 func PostInter(client Interface, sub string, jsonBody []byte) (resp *http.Response, err error) {
+	return PostInterContext(context.Background(), client, sub, jsonBody)
+}
+
+// PostInterContext is PostInter with context.
+func PostInterContext(ctx context.Context, client Interface, sub string, jsonBody []byte) (resp *http.Response, err error) {
 	opts := client.DefaultOpts()
 	for key, value := range defaultPostHeader {
 		opts.Header[key] = value
 	}
 	opts.Body = bytes.NewReader(jsonBody)
-	return DoInter(client, http.MethodPost, sub, opts)
+	return DoInterContext(ctx, client, http.MethodPost, sub, opts)
 }
 
 // DeleteInter do Redash DELETE with Interface and return result.
 func DeleteInter(client Interface, sub string, params map[string]string) (resp *http.Response, err error) {
+	return DeleteInterContext(context.Background(), client, sub, params)
+}
+
+// DeleteInterContext is DeleteInter with context.
+func DeleteInterContext(ctx context.Context, client Interface, sub string, params map[string]string) (resp *http.Response, err error) {
 	opts := client.DefaultOpts()
 	for key, value := range params {
 		opts.Params[key] = value
 	}
-	return DoInter(client, http.MethodDelete, sub, opts)
+	return DoInterContext(ctx, client, http.MethodDelete, sub, opts)
 }
 
 // DoInter do Redash apis with Interface and return result.
 func DoInter(client Interface, method, sub string, opts *Options) (resp *http.Response, err error) {
-	log.Printf("[INFO] do: %s %s", method, sub)
-	req, err := RequestInter(client, method, sub, opts)
+	return DoInterContext(context.Background(), client, method, sub, opts)
+}
+
+// DoInterContext is DoInter with context.
+func DoInterContext(ctx context.Context, client Interface, method, sub string, opts *Options) (resp *http.Response, err error) {
+	logger := clientLogger(client)
+	start := time.Now()
+	var policy *RetryPolicy
+	if rp, ok := client.(RetryPolicyer); ok {
+		policy = rp.RetryPolicy()
+	}
+	if policy != nil && policy.MaxAttempts > 1 {
+		resp, err = doRetry(ctx, client, logger, policy, method, sub, opts)
+	} else {
+		resp, err = doOnce(ctx, client, method, sub, opts)
+	}
 	if err != nil {
+		logger.Log(ctx, LevelError, "redash: request failed",
+			"method", method, "path", sub, "latency", time.Since(start), "error", err)
 		return nil, err
 	}
-	return client.HTTPClient().Do(req)
+	logger.Log(ctx, LevelInfo, "redash: request",
+		"method", method, "path", sub, "status", resp.StatusCode, "latency", time.Since(start))
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// marshalJSON marshal v without escaping html, not to escape operators
+// like ">" of alerts and sql.
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// doJSON do request with json body of in(if not nil), and decode
+// response into out(if not nil). It is helper of typed services.
+func doJSON(ctx context.Context, client Interface, method, sub string, params map[string]string, in, out interface{}) (err error) {
+	var resp *http.Response
+	switch method {
+	case http.MethodPost:
+		var body []byte
+		if in != nil {
+			if body, err = marshalJSON(in); err != nil {
+				return err
+			}
+		}
+		resp, err = PostInterContext(ctx, client, sub, body)
+	case http.MethodDelete:
+		resp, err = DeleteInterContext(ctx, client, sub, params)
+	case http.MethodGet:
+		resp, err = GetInterContext(ctx, client, sub, params)
+	default:
+		return fmt.Errorf("redash: unsupported method %s", method)
+	}
+	if err != nil {
+		return err
+	}
+	return decodeBody(resp.Body, out)
+}
+
+// getValuesJSON do GET with multi-valued params, and decode response
+// into out. It is helper of listings filtered by tags.
+func getValuesJSON(ctx context.Context, client Interface, sub string, values url.Values, out interface{}) (err error) {
+	opts := client.DefaultOpts()
+	opts.Values = values
+	resp, err := DoInterContext(ctx, client, http.MethodGet, sub, opts)
+	if err != nil {
+		return err
+	}
+	return decodeBody(resp.Body, out)
 }
 
 // RequestInter make request with Interface.
 func RequestInter(client Interface, method, sub string, opts *Options) (req *http.Request, err error) {
+	return RequestInterContext(context.Background(), client, method, sub, opts)
+}
+
+// RequestInterContext is RequestInter with context.
+func RequestInterContext(ctx context.Context, client Interface, method, sub string, opts *Options) (req *http.Request, err error) {
 	u, err := client.Url()
 	if err != nil {
 		return nil, err
 	}
+	// copy not to change url of client.
+	ru := *u
+	u = &ru
 	u.Path = path.Join(u.Path, sub)
 	values := url.Values{}
 	for key, value := range opts.Params {
 		values.Add(key, value)
 	}
-	req, err = http.NewRequest(method, u.String(), opts.Body)
-	req.URL.RawQuery = values.Encode()
+	for key, vs := range opts.Values {
+		for _, value := range vs {
+			values.Add(key, value)
+		}
+	}
+	req, err = http.NewRequestWithContext(ctx, method, u.String(), opts.Body)
 	if err != nil {
 		return nil, err
 	}
+	req.URL.RawQuery = values.Encode()
 	apikey, err := client.Apikey()
 	if err != nil {
 		return nil, err
@@ -169,53 +281,100 @@ func Get(sub string, params map[string]string) (resp *http.Response, err error)
 	return GetInter(DefaultClient, sub, params)
 }
 
+// GetContext is Get with context.
+func GetContext(ctx context.Context, sub string, params map[string]string) (resp *http.Response, err error) {
+	return GetInterContext(ctx, DefaultClient, sub, params)
+}
+
 // Post do Redash api POST and return result.
 func Post(sub string, jsonBody []byte) (resp *http.Response, err error) {
 	return PostInter(DefaultClient, sub, jsonBody)
 }
 
+// PostContext is Post with context.
+func PostContext(ctx context.Context, sub string, jsonBody []byte) (resp *http.Response, err error) {
+	return PostInterContext(ctx, DefaultClient, sub, jsonBody)
+}
+
 // Delete do Redash api DELETE and return result.
 func Delete(sub string, params map[string]string) (resp *http.Response, err error) {
 	return DeleteInter(DefaultClient, sub, params)
 }
 
+// DeleteContext is Delete with context.
+func DeleteContext(ctx context.Context, sub string, params map[string]string) (resp *http.Response, err error) {
+	return DeleteInterContext(ctx, DefaultClient, sub, params)
+}
+
 // Do do Redash apis and return result.
 func Do(method, sub string, opts *Options) (resp *http.Response, err error) {
 	return DoInter(DefaultClient, method, sub, opts)
 }
 
+// DoContext is Do with context.
+func DoContext(ctx context.Context, method, sub string, opts *Options) (resp *http.Response, err error) {
+	return DoInterContext(ctx, DefaultClient, method, sub, opts)
+}
+
 // Request make http.Request for Redash.
 func Request(method, sub string, opts *Options) (req *http.Request, err error) {
 	return RequestInter(DefaultClient, method, sub, opts)
 }
 
+// RequestContext is Request with context.
+func RequestContext(ctx context.Context, method, sub string, opts *Options) (req *http.Request, err error) {
+	return RequestInterContext(ctx, DefaultClient, method, sub, opts)
+}
+
 // Default implemet of client include Logger.
 type ClientData struct {
+	// Logger is log.Logger as before, used by NewStdLogger with
+	// LevelDebug if LevelLogger is nil.
 	*log.Logger
+	// LevelLogger is levelled logger, set by WithLogger.
+	LevelLogger Logger
 }
 
-// Default implement of client. This is provided as DefaultClient.
+// Implementation of Loggerer for ClientData.
+func (cd ClientData) ClientLogger() Logger {
+	if cd.LevelLogger != nil {
+		return cd.LevelLogger
+	}
+	if cd.Logger != nil {
+		return NewStdLogger(cd.Logger, LevelDebug)
+	}
+	return NopLogger
+}
+
+// Default implement of client. This is provided as DefaultClient,
+// and made by NewClient.
 type DefaultClientData struct {
 	ClientData
-	apikey string
-	u      *url.URL
+	apikey     string
+	u          *url.URL
+	retry      *RetryPolicy
+	httpClient *http.Client
+	timeout    time.Duration
+	header     map[string]string
+	// fromEnv enables to read url and apikey from env if they are empty.
+	fromEnv bool
 }
 
 // Implementation of apikey for DefaultClient
 func (dc DefaultClientData) Apikey() (apikey string, err error) {
-	if len(dc.apikey) < 1 {
+	if len(dc.apikey) < 1 && dc.fromEnv {
 		dc.apikey = os.Getenv(redashApikeyEnv)
 	}
 	if len(dc.apikey) < 1 {
 		return "", errors.New("invalid apikey")
 	}
-	dc.Logger.Printf("[DEBUG] apikey: [%s]", maskKey(dc.apikey))
+	dc.ClientLogger().Log(context.Background(), LevelDebug, "redash: apikey", "apikey", maskKey(dc.apikey))
 	return dc.apikey, nil
 }
 
 // Implementation of Url for DefaultClient
 func (dc DefaultClientData) Url() (u *url.URL, err error) {
-	if dc.u.String() == "" {
+	if dc.u.String() == "" && dc.fromEnv {
 		dc.u, err = url.Parse(os.Getenv(redashUrlEnv))
 		if err != nil {
 			return nil, err
@@ -226,34 +385,113 @@ func (dc DefaultClientData) Url() (u *url.URL, err error) {
 
 // Implementation of HTTPClient for DefaultClient
 func (dc DefaultClientData) HTTPClient() *http.Client {
-	return http.DefaultClient
+	c := dc.httpClient
+	if c == nil {
+		c = http.DefaultClient
+	}
+	if dc.timeout > 0 {
+		// copy not to change the given client.
+		tc := *c
+		tc.Timeout = dc.timeout
+		return &tc
+	}
+	return c
 }
 
 // Implementation of DefaultOpts for DefaultClient
 func (dc DefaultClientData) DefaultOpts() *Options {
-	return defaultOpts()
+	opts := defaultOpts()
+	for key, value := range dc.header {
+		opts.Header[key] = value
+	}
+	return opts
 }
 
-// Create a new defaultClient
-func NewDefaultClient() *DefaultClientData {
-	var u *url.URL
-	var err error
-	if ue := os.Getenv(redashUrlEnv); ue != "" {
-		u, err = url.Parse(os.Getenv(redashUrlEnv))
-		if err != nil {
-			return nil
-		}
-		log.Printf("[DEBUG] set url: %s", u)
-	} else {
-		u = &url.URL{}
+// Implementation of RetryPolicyer for DefaultClient
+func (dc DefaultClientData) RetryPolicy() *RetryPolicy {
+	return dc.retry
+}
+
+// SetRetryPolicy set retry policy of client, nil disables retry.
+func (dc *DefaultClientData) SetRetryPolicy(policy *RetryPolicy) {
+	dc.retry = policy
+}
+
+// Option is functional option for NewClient.
+type Option func(*DefaultClientData)
+
+// WithHTTPClient set http.Client to do requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(dc *DefaultClientData) {
+		dc.httpClient = httpClient
 	}
+}
+
+// WithTimeout set timeout of each request. If WithHTTPClient is also
+// given, in any order, the client is copied and its Timeout is
+// overwritten. It is not applied to streamed results, like
+// DownloadById and GetRowReaderById, not to cut large result off, so
+// use ctx of the Context variants to bound them.
+func WithTimeout(timeout time.Duration) Option {
+	return func(dc *DefaultClientData) {
+		dc.timeout = timeout
+	}
+}
+
+// WithLogger set logger of client, default is NopLogger.
+// Use NewSlogLogger or NewStdLogger to log to slog or log package.
+func WithLogger(logger Logger) Option {
+	return func(dc *DefaultClientData) {
+		dc.LevelLogger = logger
+	}
+}
+
+// WithUserAgent set User-Agent header instead of default one.
+func WithUserAgent(userAgent string) Option {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithHeader add default header sent with every request.
+func WithHeader(key, value string) Option {
+	return func(dc *DefaultClientData) {
+		dc.header[key] = value
+	}
+}
+
+// WithRetryPolicy set retry policy, nil disables retry.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(dc *DefaultClientData) {
+		dc.retry = policy
+	}
+}
+
+// NewClient create a new client for Redash at rawurl with apikey.
+// Unlike DefaultClient, it does not read REDASH_URL and REDASH_APIKEY.
+func NewClient(rawurl, apikey string, opts ...Option) (*DefaultClientData, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	retry := DefaultRetryPolicy
 	dcd := &DefaultClientData{
-		apikey: os.Getenv(redashApikeyEnv),
+		apikey: apikey,
 		u:      u,
+		retry:  &retry,
+		header: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(dcd)
+	}
+	return dcd, nil
+}
+
+// Create a new defaultClient
+func NewDefaultClient() *DefaultClientData {
+	dcd, err := NewClient(os.Getenv(redashUrlEnv), os.Getenv(redashApikeyEnv))
+	if err != nil {
+		return nil
 	}
-	dcd.Logger = &log.Logger{}
-	dcd.Logger.SetOutput(os.Stdout)
-	dcd.Logger.SetFlags(log.Ldate | log.Ltime)
+	dcd.fromEnv = true
 	return dcd
 }
 
@@ -1,7 +1,9 @@
 package redash
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -10,6 +12,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"bytes"
 	"net/url"
@@ -36,11 +39,11 @@ func TestDefaultClient(t *testing.T) {
 	}
 
 	if client.HTTPClient() != http.DefaultClient {
-		t.Fatalf("HTTPClient is bad. want: %q, have: %q", http.DefaultClient, client.HTTPClient())
+		t.Fatalf("HTTPClient is bad. want: %v, have: %v", http.DefaultClient, client.HTTPClient())
 	}
 
 	if !reflect.DeepEqual(client.DefaultOpts(), defaultOpts()) {
-		t.Fatalf("DefaultOptions is bad. want: %q, have: %q", defaultOpts, client.DefaultOpts())
+		t.Fatalf("DefaultOptions is bad. want: %v, have: %v", defaultOpts(), client.DefaultOpts())
 	}
 }
 
@@ -135,17 +138,17 @@ func TestPost(t *testing.T) {
 			}
 			var postJson postOpt
 			if err := json.Unmarshal(buf, &postJson); err != nil {
-				t.Fatalf("Failed Json Decode have: %q err: %v", postJson, err)
+				t.Fatalf("Failed Json Decode have: %v err: %v", postJson, err)
 			}
 			if postJson == *new(postOpt) {
-				t.Fatalf("Length of postJson was 0 have: %q err: %v", postJson, err)
+				t.Fatalf("Length of postJson was 0 have: %v err: %v", postJson, err)
 			}
 			if postJson.Name != "abc" {
-				http.Error(w, fmt.Sprintf("Failed send data have: %q %q", r.Body, postJson), http.StatusInternalServerError)
+				http.Error(w, fmt.Sprintf("Failed send data have: %v %v", r.Body, postJson), http.StatusInternalServerError)
 				return
 			}
 			if !postJson.Opts.Opt1 {
-				http.Error(w, fmt.Sprintf("Failed send child data have: %q %q", r.Body, postJson), http.StatusInternalServerError)
+				http.Error(w, fmt.Sprintf("Failed send child data have: %v %v", r.Body, postJson), http.StatusInternalServerError)
 				return
 			}
 			w.Header().Set("Content-Type", "application/json")
@@ -321,10 +324,10 @@ func TestRequest(t *testing.T) {
 	}
 	var postJson postOpt
 	if err := json.Unmarshal(buf, &postJson); err != nil {
-		t.Fatalf("Failed Json Decode have: %q err: %v", postJson, err)
+		t.Fatalf("Failed Json Decode have: %v err: %v", postJson, err)
 	}
 	if postJson.Name != "abc" {
-		t.Fatalf("Failed prepare data have: %q %q", req.Body, postJson)
+		t.Fatalf("Failed prepare data have: %v %v", req.Body, postJson)
 	}
 }
 
@@ -374,11 +377,11 @@ func TestOriginalClient(t *testing.T) {
 	hc := client.HTTPClient()
 	nc := &http.Client{}
 	if !reflect.DeepEqual(hc, nc) {
-		t.Fatalf("HTTPClient is bad. want: %q, have: %q", nc, hc)
+		t.Fatalf("HTTPClient is bad. want: %v, have: %v", nc, hc)
 	}
 
 	if !reflect.DeepEqual(client.DefaultOpts(), defaultOpts()) {
-		t.Fatalf("DefaultOpts is bad. want: %q, have: %q", defaultOpts(), client.DefaultOpts())
+		t.Fatalf("DefaultOpts is bad. want: %v, have: %v", defaultOpts(), client.DefaultOpts())
 	}
 
 }
@@ -442,7 +445,7 @@ func TestGetInter(t *testing.T) {
 		t.Fatalf("resp is bad. want: %q, have: %q", want, have)
 	}
 	if !reflect.DeepEqual(client.DefaultOpts(), defaultOpts()) {
-		t.Fatalf("DefaultOpts is bad. want: %q, have: %q", defaultOpts(), client.DefaultOpts())
+		t.Fatalf("DefaultOpts is bad. want: %v, have: %v", defaultOpts(), client.DefaultOpts())
 	}
 }
 
@@ -468,17 +471,17 @@ func TestPostInter(t *testing.T) {
 			}
 			var postJson postOpt
 			if err := json.Unmarshal(buf, &postJson); err != nil {
-				t.Fatalf("Failed Json Decode have: %q err: %v", postJson, err)
+				t.Fatalf("Failed Json Decode have: %v err: %v", postJson, err)
 			}
 			if postJson == *new(postOpt) {
-				t.Fatalf("Length of postJson was 0 have: %q err: %v", postJson, err)
+				t.Fatalf("Length of postJson was 0 have: %v err: %v", postJson, err)
 			}
 			if postJson.Name != "abc" {
-				http.Error(w, fmt.Sprintf("Failed send data have: %q %q", r.Body, postJson), http.StatusInternalServerError)
+				http.Error(w, fmt.Sprintf("Failed send data have: %v %v", r.Body, postJson), http.StatusInternalServerError)
 				return
 			}
 			if !postJson.Opts.Opt1 {
-				http.Error(w, fmt.Sprintf("Failed send child data have: %q %q", r.Body, postJson), http.StatusInternalServerError)
+				http.Error(w, fmt.Sprintf("Failed send child data have: %v %v", r.Body, postJson), http.StatusInternalServerError)
 				return
 			}
 			w.Header().Set("Content-Type", "application/json")
@@ -522,7 +525,7 @@ func TestPostInter(t *testing.T) {
 		t.Fatalf("resp is bad. want: %q, have: %q", want, have)
 	}
 	if !reflect.DeepEqual(client.DefaultOpts(), defaultOpts()) {
-		t.Fatalf("DefaultOpts is bad. want: %q, have: %q", defaultOpts(), client.DefaultOpts())
+		t.Fatalf("DefaultOpts is bad. want: %v, have: %v", defaultOpts(), client.DefaultOpts())
 	}
 }
 
@@ -581,7 +584,7 @@ func TestDeleteInter(t *testing.T) {
 		t.Fatalf("resp is bad. want: %q, have: %q", want, have)
 	}
 	if !reflect.DeepEqual(client.DefaultOpts(), defaultOpts()) {
-		t.Fatalf("DefaultOpts is bad. want: %q, have: %q", defaultOpts(), client.DefaultOpts())
+		t.Fatalf("DefaultOpts is bad. want: %v, have: %v", defaultOpts(), client.DefaultOpts())
 	}
 }
 
@@ -641,7 +644,7 @@ func TestDoInter(t *testing.T) {
 		t.Fatalf("resp is bad. want: %q, have: %q", want, have)
 	}
 	if !reflect.DeepEqual(client.DefaultOpts(), defaultOpts()) {
-		t.Fatalf("DefaultOpts is bad. want: %q, have: %q", defaultOpts(), client.DefaultOpts())
+		t.Fatalf("DefaultOpts is bad. want: %v, have: %v", defaultOpts(), client.DefaultOpts())
 	}
 }
 
@@ -672,12 +675,125 @@ func TestRequestInter(t *testing.T) {
 	}
 	var postJson postOpt
 	if err := json.Unmarshal(buf, &postJson); err != nil {
-		t.Fatalf("Failed Json Decode have: %q err: %v", postJson, err)
+		t.Fatalf("Failed Json Decode have: %v err: %v", postJson, err)
 	}
 	if postJson.Name != "abc" {
-		t.Fatalf("Failed prepare data have: %q %q", req.Body, postJson)
+		t.Fatalf("Failed prepare data have: %v %v", req.Body, postJson)
 	}
 	if !reflect.DeepEqual(client.DefaultOpts(), defaultOpts()) {
-		t.Fatalf("DefaultOpts is bad. want: %q, have: %q", defaultOpts(), client.DefaultOpts())
+		t.Fatalf("DefaultOpts is bad. want: %v, have: %v", defaultOpts(), client.DefaultOpts())
+	}
+}
+
+func TestDoInterContext(t *testing.T) {
+
+	done := make(chan struct{})
+	defer close(done)
+	tgs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer tgs.Close()
+	client := mockClientData{MockUrl: tgs.URL}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := DoInterContext(ctx, client, http.MethodGet, "api/slow", defaultOpts())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Error is not DeadlineExceeded, have: %v", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = QueriesS{client}.GetQueryIdTypedContext(ctx, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Error is not Canceled, have: %v", err)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"server": %q, "auth": %q, "ua": %q, "x": %q}`,
+				name, r.Header.Get("Authorization"), r.Header.Get("User-Agent"), r.Header.Get("X-Test"))
+		}))
+	}
+	staging, production := newServer("staging"), newServer("production")
+	defer staging.Close()
+	defer production.Close()
+
+	logger := NopLogger
+	httpClient := &http.Client{}
+	stgClient, err := NewClient(staging.URL, "stgkey", WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prdClient, err := NewClient(production.URL, "prdkey",
+		WithLogger(logger),
+		WithHTTPClient(httpClient),
+		WithTimeout(time.Second),
+		WithUserAgent("test-agent"),
+		WithHeader("X-Test", "x"),
+		WithRetryPolicy(nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type check struct {
+		Server, Auth, UA, X string
+	}
+	do := func(client Interface) (c check) {
+		resp, err := GetInter(client, "api/check", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = decodeBody(resp.Body, &c); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	if have, want := do(stgClient), (check{"staging", "Key stgkey", ua, ""}); have != want {
+		t.Fatalf("Staging request is bad,\n want: %v,\n have: %v\n", want, have)
+	}
+	if have, want := do(prdClient), (check{"production", "Key prdkey", "test-agent", "x"}); have != want {
+		t.Fatalf("Production request is bad,\n want: %v,\n have: %v\n", want, have)
+	}
+	// Url of client must not be changed by requests.
+	if u, _ := stgClient.Url(); u.String() != staging.URL {
+		t.Fatalf("Url is changed,\n want: %q,\n have: %q\n", staging.URL, u)
+	}
+
+	if hc := prdClient.HTTPClient(); hc == httpClient || hc.Timeout != time.Second || httpClient.Timeout != 0 {
+		t.Fatalf("HTTPClient is bad, have: %v", hc)
+	}
+	// Timeout does not depend on order of options.
+	reversed, err := NewClient(production.URL, "prdkey", WithTimeout(time.Second), WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hc := reversed.HTTPClient(); hc == httpClient || hc.Timeout != time.Second {
+		t.Fatalf("HTTPClient is bad, have: %v", hc)
+	}
+	if hc := stgClient.HTTPClient(); hc != http.DefaultClient {
+		t.Fatalf("HTTPClient is bad, have: %v", hc)
+	}
+	if prdClient.RetryPolicy() != nil {
+		t.Fatalf("RetryPolicy should be nil, have: %v", prdClient.RetryPolicy())
+	}
+
+	// NewClient does not read env.
+	noKeyClient, err := NewClient(staging.URL, "", WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = noKeyClient.Apikey(); err == nil {
+		t.Fatal("Apikey should not be read from env")
+	}
+	if _, err = NewClient("://bad", "key"); err == nil {
+		t.Fatal("Bad url should be error")
 	}
 }
@@ -0,0 +1,411 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ynishi/redash"
+)
+
+var commands = []command{
+	{"queries", "[-my] [-tag tag,...] [-page-size n]", "list queries", runQueries},
+	{"search", "<text>", "search queries", runSearch},
+	{"show", "[-json] <query id>", "show a query", runShow},
+	{"sources", "", "list data sources", runSources},
+	{"run", "-d <data source> [-max-age sec] [-timeout d] [-format f] [sql|-]", "run sql on a data source", runRun},
+	{"refresh", "[-timeout d] [-format f] <query id>", "refresh a query and wait for the result", runRefresh},
+	{"download", "[-format f] [-result id] [-o file] <query id>", "download result as csv, tsv, json or xlsx", runDownload},
+	{"jobs", "status|cancel <job id>", "show or cancel a job", runJobs},
+}
+
+// newFlagSet make flag set of command, output to errOut.
+func newFlagSet(a *app, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("redash "+name, flag.ContinueOnError)
+	fs.SetOutput(a.errOut)
+	return fs
+}
+
+// argId parse one id arg.
+func argId(fs *flag.FlagSet) (int, error) {
+	if fs.NArg() != 1 {
+		return 0, errUsage
+	}
+	id, err := strconv.Atoi(fs.Arg(0))
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %q", fs.Arg(0))
+	}
+	return id, nil
+}
+
+// printQueries print queries as table.
+func printQueries(w io.Writer, seq iter.Seq2[redash.ResponseQuery, error]) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tDATA SOURCE\tTAGS\tUPDATED")
+	for rq, err := range seq {
+		if err != nil {
+			tw.Flush()
+			return err
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\n", rq.Id, rq.Name, rq.DataSourceId, strings.Join(rq.Tags, ","), rq.UpdatedAt)
+	}
+	return tw.Flush()
+}
+
+// slice make seq of queries.
+func slice(rqs []redash.ResponseQuery) iter.Seq2[redash.ResponseQuery, error] {
+	return func(yield func(redash.ResponseQuery, error) bool) {
+		for _, rq := range rqs {
+			if !yield(rq, nil) {
+				return
+			}
+		}
+	}
+}
+
+func runQueries(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "queries")
+	my := fs.Bool("my", false, "list only my queries")
+	tags := fs.String("tag", "", "comma separated `tags` queries have")
+	pageSize := fs.Int("page-size", 100, "page `size` to fetch")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errUsage
+	}
+	qs := a.queries()
+	switch {
+	case *my && *tags != "":
+		return fmt.Errorf("-my and -tag can not be used together")
+	case *my:
+		return printQueries(a.out, qs.IterMyContext(ctx, *pageSize))
+	case *tags != "":
+		return printQueries(a.out, qs.IterQueryByTagsContext(ctx, *pageSize, strings.Split(*tags, ",")...))
+	}
+	return printQueries(a.out, qs.IterQueryContext(ctx, *pageSize))
+}
+
+func runSearch(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "search")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errUsage
+	}
+	rqs, err := a.queries().GetSearchTypedContext(ctx, strings.Join(fs.Args(), " "))
+	if err != nil {
+		return err
+	}
+	return printQueries(a.out, slice(rqs))
+}
+
+func runShow(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "show")
+	asJSON := fs.Bool("json", false, "print query as json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := argId(fs)
+	if err != nil {
+		return err
+	}
+	rq, err := a.queries().GetQueryIdTypedContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		enc := json.NewEncoder(a.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rq)
+	}
+	tw := tabwriter.NewWriter(a.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Id:\t%d\n", rq.Id)
+	fmt.Fprintf(tw, "Name:\t%s\n", rq.Name)
+	if rq.Description != "" {
+		fmt.Fprintf(tw, "Description:\t%s\n", rq.Description)
+	}
+	fmt.Fprintf(tw, "Data source:\t%d\n", rq.DataSourceId)
+	if len(rq.Tags) > 0 {
+		fmt.Fprintf(tw, "Tags:\t%s\n", strings.Join(rq.Tags, ", "))
+	}
+	if sc := rq.Schedule; sc != nil && sc.Interval > 0 {
+		fmt.Fprintf(tw, "Schedule:\tevery %ds", sc.Interval)
+		if sc.Time != "" {
+			fmt.Fprintf(tw, " at %s", sc.Time)
+		}
+		if sc.DayOfWeek != "" {
+			fmt.Fprintf(tw, " on %s", sc.DayOfWeek)
+		}
+		if sc.Until != "" {
+			fmt.Fprintf(tw, " until %s", sc.Until)
+		}
+		fmt.Fprintln(tw)
+	}
+	fmt.Fprintf(tw, "Updated:\t%s\n", rq.UpdatedAt)
+	for _, p := range rq.Options.Parameters {
+		fmt.Fprintf(tw, "Parameter:\t%s (%s) default %v\n", p.Name, p.Type, p.Value)
+	}
+	for _, v := range rq.Visualizations {
+		fmt.Fprintf(tw, "Visualization:\t%d %s (%s)\n", v.Id, v.Name, v.Type)
+	}
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(a.out, "\n%s\n", rq.Query)
+	return err
+}
+
+func runSources(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "sources")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errUsage
+	}
+	dss, err := redash.DataSourcesS{Client: a.client}.GetDataSourcesContext(ctx)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(a.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tTYPE")
+	for _, ds := range dss {
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", ds.Id, ds.Name, ds.Type)
+	}
+	return tw.Flush()
+}
+
+// dataSourceId resolve id or name of data source.
+func dataSourceId(ctx context.Context, a *app, s string) (int, error) {
+	if id, err := strconv.Atoi(s); err == nil {
+		return id, nil
+	}
+	ds, err := redash.DataSourcesS{Client: a.client}.GetDataSourceByNameContext(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+	return ds.Id, nil
+}
+
+// printResult print result in format, table, csv or json.
+func printResult(w io.Writer, qr *redash.QueryResult, format string) error {
+	records, err := qr.Data.Records()
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "json":
+		rows := make([]map[string]interface{}, len(records))
+		for i, r := range records {
+			rows[i] = r.Map()
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err = cw.Write(columnNames(qr.Data.Columns)); err != nil {
+			return err
+		}
+		for _, r := range records {
+			if err = cw.Write(formatValues(r.Values, "")); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(columnNames(qr.Data.Columns), "\t"))
+		for _, r := range records {
+			fmt.Fprintln(tw, strings.Join(formatValues(r.Values, "NULL"), "\t"))
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func columnNames(columns []redash.Column) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// formatValues format values to print, null is shown as null.
+func formatValues(values []interface{}, null string) []string {
+	ss := make([]string, len(values))
+	for i, v := range values {
+		switch x := v.(type) {
+		case nil:
+			ss[i] = null
+		case time.Time:
+			ss[i] = x.Format(time.RFC3339)
+		default:
+			ss[i] = fmt.Sprint(x)
+		}
+	}
+	return ss
+}
+
+// executeFlags add flags to wait for result.
+func executeFlags(fs *flag.FlagSet) (timeout *time.Duration, format *string) {
+	timeout = fs.Duration("timeout", 0, "max `duration` to wait, 0 means no timeout")
+	format = fs.String("format", "table", "output `format`, table, csv or json")
+	return timeout, format
+}
+
+func runRun(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "run")
+	ds := fs.String("d", "", "id or name of data `source`")
+	maxAge := fs.Int("max-age", 0, "max age `seconds` of cached result")
+	timeout, format := executeFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *ds == "" {
+		return errUsage
+	}
+	query := strings.Join(fs.Args(), " ")
+	if query == "" || query == "-" {
+		buf, err := io.ReadAll(a.in)
+		if err != nil {
+			return err
+		}
+		query = string(buf)
+	}
+	if strings.TrimSpace(query) == "" {
+		return errUsage
+	}
+	id, err := dataSourceId(ctx, a, *ds)
+	if err != nil {
+		return err
+	}
+	opts := redash.DefaultExecuteOptions
+	opts.MaxAge = *maxAge
+	opts.Timeout = *timeout
+	qr, err := a.queries().Execute(ctx, query, id, &opts)
+	if err != nil {
+		return err
+	}
+	return printResult(a.out, qr, *format)
+}
+
+func runRefresh(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "refresh")
+	timeout, format := executeFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := argId(fs)
+	if err != nil {
+		return err
+	}
+	opts := redash.DefaultExecuteOptions
+	opts.Timeout = *timeout
+	qr, err := a.queries().Refresh(ctx, id, &opts)
+	if err != nil {
+		return err
+	}
+	return printResult(a.out, qr, *format)
+}
+
+func runDownload(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "download")
+	format := fs.String("format", "csv", "file `format`, csv, tsv, json or xlsx")
+	resultId := fs.Int("result", 0, "query result `id`, latest result if 0")
+	output := fs.String("o", "", "output `file`, stdout if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := argId(fs)
+	if err != nil {
+		return err
+	}
+	w := a.out
+	var f *os.File
+	var progress redash.Progress
+	if *output != "" {
+		if f, err = os.Create(*output); err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+		progress = func(written, total int64) {
+			if total > 0 {
+				fmt.Fprintf(a.errOut, "\r%d/%d bytes", written, total)
+			} else {
+				fmt.Fprintf(a.errOut, "\r%d bytes", written)
+			}
+		}
+	}
+	qs := a.queries()
+	if *resultId != 0 {
+		_, err = qs.DownloadByIdContext(ctx, w, id, *resultId, redash.Format(*format), progress)
+	} else {
+		_, err = qs.DownloadByQueryIdContext(ctx, w, id, redash.Format(*format), progress)
+	}
+	if progress != nil {
+		fmt.Fprintln(a.errOut)
+	}
+	if err != nil {
+		return err
+	}
+	if f != nil {
+		return f.Close()
+	}
+	return nil
+}
+
+// jobStatus is name of job status.
+var jobStatus = map[int]string{
+	redash.JobPending:   "pending",
+	redash.JobStarted:   "started",
+	redash.JobSuccess:   "success",
+	redash.JobFailure:   "failure",
+	redash.JobCancelled: "cancelled",
+}
+
+func runJobs(ctx context.Context, a *app, args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	qs := a.queries()
+	jobId := args[1]
+	switch args[0] {
+	case "status":
+		job, err := qs.GetJobTypedContext(ctx, jobId)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(a.out, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "Id:\t%s\n", job.Job.Id)
+		fmt.Fprintf(tw, "Status:\t%s\n", jobStatus[job.Job.Status])
+		if job.Job.QueryResultId != 0 {
+			fmt.Fprintf(tw, "Query result:\t%d\n", job.Job.QueryResultId)
+		}
+		if job.Job.Error != "" {
+			fmt.Fprintf(tw, "Error:\t%s\n", job.Job.Error)
+		}
+		return tw.Flush()
+	case "cancel":
+		return qs.DeleteJobTypedContext(ctx, jobId)
+	}
+	return errUsage
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	queryResp  = `{"id": 1, "name": "hello", "query": "select 1", "data_source_id": 1, "tags": ["kpi"], "schedule": {"interval": 86400, "time": "02:00", "day_of_week": null, "until": null}, "options": {"parameters": [{"name": "n", "type": "number", "value": 1}]}}`
+	pagingResp = `{"count": 1, "page": 1, "page_size": 100, "results": [` + queryResp + `]}`
+	jobResp    = `{"job": {"id": "x", "status": 3, "query_result_id": 2}}`
+	resultResp = `{"query_result": {"id": 2, "data": {
+  "columns": [{"name": "id", "type": "integer"}, {"name": "name", "type": "string"}],
+  "rows": [{"id": 1, "name": "a"}, {"id": 2, "name": null}]}}}`
+)
+
+// runWith run command with mock Redash, and return exit code and
+// outputs.
+func runWith(t *testing.T, stdin string, args ...string) (code int, out, errOut string) {
+	routes := map[string]string{
+		"GET /api/queries":               pagingResp,
+		"GET /api/queries/search":        "[" + queryResp + "]",
+		"GET /api/queries/1":             queryResp,
+		"GET /api/data_sources":          `[{"id": 1, "name": "pg", "type": "pg"}]`,
+		"POST /api/query_results":        jobResp,
+		"POST /api/queries/1/refresh":    jobResp,
+		"GET /api/query_results/2":       resultResp,
+		"GET /api/queries/1/results.csv": "id,name\n1,a\n",
+		"GET /api/jobs/x":                jobResp,
+		"DELETE /api/jobs/x":             "null",
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			http.Error(w, `{"message": "not found"}`, http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer ts.Close()
+	env := map[string]string{"REDASH_URL": ts.URL, "REDASH_APIKEY": "abcdefg"}
+	var stdout, stderr bytes.Buffer
+	code = run(context.Background(), args, envOf(env), strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	cases := []struct {
+		args  []string
+		stdin string
+		want  []string
+	}{
+		{[]string{"queries"}, "", []string{"ID  NAME", "1   hello  1            kpi"}},
+		{[]string{"queries", "-tag", "kpi"}, "", []string{"hello"}},
+		{[]string{"search", "hel"}, "", []string{"hello"}},
+		{[]string{"show", "1"}, "", []string{"Name:", "hello", "Schedule:", "every 86400s at 02:00", "Parameter:", "n (number) default 1", "select 1"}},
+		{[]string{"show", "-json", "1"}, "", []string{`"name": "hello"`}},
+		{[]string{"sources"}, "", []string{"1   pg    pg"}},
+		{[]string{"run", "-d", "pg", "select 1"}, "", []string{"id  name", "2   NULL"}},
+		{[]string{"run", "-d", "1", "-format", "csv"}, "select 1", []string{"id,name\n1,a\n2,\n"}},
+		{[]string{"refresh", "-format", "json", "1"}, "", []string{`"name": null`}},
+		{[]string{"download", "1"}, "", []string{"id,name\n1,a\n"}},
+		{[]string{"jobs", "status", "x"}, "", []string{"success", "Query result:  2"}},
+		{[]string{"jobs", "cancel", "x"}, "", []string{""}},
+		{[]string{"help"}, "", []string{"commands:", "refresh"}},
+	}
+	for _, c := range cases {
+		code, out, errOut := runWith(t, c.stdin, c.args...)
+		if code != 0 {
+			t.Errorf("%v: exit %d, %s", c.args, code, errOut)
+			continue
+		}
+		for _, want := range c.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("%v: output does not contain %q, have:\n%s", c.args, want, out)
+			}
+		}
+	}
+}
+
+func TestCommandErrors(t *testing.T) {
+	cases := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{[]string{"nothing"}, 2, "unknown command"},
+		{[]string{"show"}, 2, "usage: redash show"},
+		{[]string{"run", "select 1"}, 2, "usage: redash run"},
+		{[]string{"jobs", "wait", "x"}, 2, "usage: redash jobs"},
+		{[]string{"show", "2"}, 1, "not found"},
+		{[]string{"queries", "-my", "-tag", "a"}, 1, "can not be used together"},
+	}
+	for _, c := range cases {
+		code, _, errOut := runWith(t, "", c.args...)
+		if code != c.code || !strings.Contains(errOut, c.want) {
+			t.Errorf("%v: want %d %q, have: %d %s", c.args, c.code, c.want, code, errOut)
+		}
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.csv")
+	code, _, errOut := runWith(t, "", "download", "-o", path, "1")
+	if code != 0 {
+		t.Fatalf("exit %d, %s", code, errOut)
+	}
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "id,name\n1,a\n" || !strings.Contains(errOut, "12/12 bytes") {
+		t.Fatalf("Download is bad, have: %q, %q", buf, errOut)
+	}
+}
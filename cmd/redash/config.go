@@ -0,0 +1,120 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultProfile is used when no profile is given and env is not set.
+const defaultProfile = "default"
+
+// profile is connection setting to Redash.
+type profile struct {
+	Url    string
+	Apikey string
+}
+
+// parseProfiles parse profile file like ini, sections are profile
+// names and keys are url and apikey. Lines starting with "#" or ";"
+// are comments.
+//
+//	[default]
+//	url = https://redash.example.com
+//	apikey = abc...
+func parseProfiles(r io.Reader) (profiles map[string]profile, err error) {
+	profiles = make(map[string]profile)
+	name := ""
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name = strings.TrimSpace(line[1 : len(line)-1])
+			profiles[name] = profile{}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("profile line %d: invalid line %q", n, line)
+		}
+		p := profiles[name]
+		switch strings.TrimSpace(key) {
+		case "url":
+			p.Url = strings.TrimSpace(value)
+		case "apikey":
+			p.Apikey = strings.TrimSpace(value)
+		default:
+			return nil, fmt.Errorf("profile line %d: unknown key %q", n, strings.TrimSpace(key))
+		}
+		profiles[name] = p
+	}
+	return profiles, s.Err()
+}
+
+// profilePath return path of profile file, REDASH_CONFIG or
+// redash/config in user config dir.
+func profilePath(getenv func(string) string) (string, error) {
+	if p := getenv("REDASH_CONFIG"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "redash", "config"), nil
+}
+
+// loadProfile return setting to connect. If name is given, the profile
+// is used. Otherwise REDASH_URL and REDASH_APIKEY are used if set, or
+// default profile.
+func loadProfile(getenv func(string) string, name string) (p profile, err error) {
+	if name == "" {
+		p = profile{Url: getenv("REDASH_URL"), Apikey: getenv("REDASH_APIKEY")}
+		if p.Url != "" && p.Apikey != "" {
+			return p, nil
+		}
+		name = defaultProfile
+	}
+	path, err := profilePath(getenv)
+	if err != nil {
+		return p, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) && name == defaultProfile {
+		return p, errors.New("REDASH_URL and REDASH_APIKEY are not set, and no profile file " + path)
+	}
+	if err != nil {
+		return p, err
+	}
+	defer f.Close()
+	profiles, err := parseProfiles(f)
+	if err != nil {
+		return p, fmt.Errorf("%s: %w", path, err)
+	}
+	found, ok := profiles[name]
+	if !ok {
+		return p, fmt.Errorf("profile %q is not in %s", name, path)
+	}
+	// env fills the profile, not to write apikey in file.
+	if found.Url == "" {
+		found.Url = getenv("REDASH_URL")
+	}
+	if found.Apikey == "" {
+		found.Apikey = getenv("REDASH_APIKEY")
+	}
+	if found.Url == "" || found.Apikey == "" {
+		return p, fmt.Errorf("profile %q needs url and apikey", name)
+	}
+	return found, nil
+}
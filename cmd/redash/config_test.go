@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profileFile = `# redash profiles
+[default]
+url = https://redash.example.com
+apikey = abc
+
+[prod]
+url = https://prod.example.com
+`
+
+// envOf make getenv of map.
+func envOf(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func TestParseProfiles(t *testing.T) {
+	profiles, err := parseProfiles(strings.NewReader(profileFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 2 || profiles["default"].Apikey != "abc" || profiles["prod"].Url != "https://prod.example.com" {
+		t.Fatalf("Profiles is bad, have: %+v", profiles)
+	}
+	for _, bad := range []string{"url = x\n", "[a]\nuser = x\n", "[a]\nurl\n"} {
+		if _, err = parseProfiles(strings.NewReader(bad)); err == nil {
+			t.Errorf("Error is not returned for %q", bad)
+		}
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(profileFile), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		env  map[string]string
+		name string
+		want profile
+	}{
+		{map[string]string{"REDASH_CONFIG": path}, "", profile{"https://redash.example.com", "abc"}},
+		{map[string]string{"REDASH_CONFIG": path, "REDASH_URL": "http://localhost", "REDASH_APIKEY": "xyz"}, "", profile{"http://localhost", "xyz"}},
+		{map[string]string{"REDASH_CONFIG": path, "REDASH_URL": "http://localhost", "REDASH_APIKEY": "xyz"}, "prod", profile{"https://prod.example.com", "xyz"}},
+	}
+	for _, c := range cases {
+		p, err := loadProfile(envOf(c.env), c.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p != c.want {
+			t.Errorf("Profile is not match, want: %+v, have: %+v", c.want, p)
+		}
+	}
+
+	if _, err := loadProfile(envOf(map[string]string{"REDASH_CONFIG": path}), "prod"); err == nil {
+		t.Error("Error is not returned for profile without apikey")
+	}
+	if _, err := loadProfile(envOf(map[string]string{"REDASH_CONFIG": path}), "nothing"); err == nil {
+		t.Error("Error is not returned for unknown profile")
+	}
+	if _, err := loadProfile(envOf(map[string]string{"REDASH_CONFIG": path + ".none"}), ""); err == nil {
+		t.Error("Error is not returned without env and profile file")
+	}
+}
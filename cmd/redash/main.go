@@ -0,0 +1,123 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Command redash is command line tool for everyday Redash tasks.
+//
+//	redash [-profile name] <command> [flags] [args]
+//
+// Connection is set by REDASH_URL and REDASH_APIKEY, or by profile file
+// (REDASH_CONFIG, default redash/config in user config dir) like:
+//
+//	[default]
+//	url = https://redash.example.com
+//	apikey = abc...
+//
+// Run "redash help" to see commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/ynishi/redash"
+)
+
+// app is environment of a command.
+type app struct {
+	client redash.Interface
+	in     io.Reader
+	out    io.Writer
+	errOut io.Writer
+}
+
+func (a *app) queries() redash.QueriesS {
+	return redash.QueriesS{Client: a.client}
+}
+
+// command is a subcommand.
+type command struct {
+	name  string
+	args  string
+	short string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+// errUsage is returned for bad args, usage of command is printed.
+var errUsage = errors.New("bad usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Getenv, os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run run command by args and return exit code.
+func run(ctx context.Context, args []string, getenv func(string) string, in io.Reader, out, errOut io.Writer) int {
+	fs := flag.NewFlagSet("redash", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	name := fs.String("profile", getenv("REDASH_PROFILE"), "profile `name` in profile file")
+	fs.Usage = func() { usage(fs, errOut) }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 || fs.Arg(0) == "help" {
+		usage(fs, out)
+		return 0
+	}
+	cmd := findCommand(fs.Arg(0))
+	if cmd == nil {
+		fmt.Fprintf(errOut, "redash: unknown command %q\n", fs.Arg(0))
+		usage(fs, errOut)
+		return 2
+	}
+	p, err := loadProfile(getenv, *name)
+	if err != nil {
+		fmt.Fprintf(errOut, "redash: %v\n", err)
+		return 1
+	}
+	client, err := redash.NewClient(p.Url, p.Apikey)
+	if err != nil {
+		fmt.Fprintf(errOut, "redash: %v\n", err)
+		return 1
+	}
+	a := &app{client: client, in: in, out: out, errOut: errOut}
+	if err = cmd.run(ctx, a, fs.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(errOut, "usage: redash %s %s\n", cmd.name, cmd.args)
+			return 2
+		}
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(errOut, "redash %s: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+func usage(fs *flag.FlagSet, w io.Writer) {
+	fmt.Fprintln(w, "usage: redash [-profile name] <command> [flags] [args]")
+	fmt.Fprintln(w, "\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-12s %s\n", c.name, c.short)
+	}
+	fmt.Fprintln(w, "\nflags:")
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
@@ -0,0 +1,271 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Default Dashboards
+var Dashboards = &DashboardsS{DefaultClient}
+
+// interface of make Dashboards endpoint.
+type Dashboardser interface {
+	Dashboards(string) string
+}
+
+// Default struct for dashboards.
+type DashboardsS struct {
+	Client Interface
+}
+
+// Default implement of Dashboards.
+func (ds DashboardsS) Dashboards(s string) (rs string) {
+	return "/api/dashboards/" + s
+}
+
+// DashboardLayout is legacy layout of dashboard, rows of widget ids.
+// Redash returns it as json array, or as json string of array in old
+// versions, both are accepted.
+type DashboardLayout [][]int
+
+// UnmarshalJSON accept layout as array or string of array.
+func (dl *DashboardLayout) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		if s == "" {
+			*dl = nil
+			return nil
+		}
+		b = []byte(s)
+	}
+	var layout [][]int
+	if err := json.Unmarshal(b, &layout); err != nil {
+		return err
+	}
+	*dl = layout
+	return nil
+}
+
+// Wrap Redash dashboard.
+type Dashboard struct {
+	Id                      int             `json:"id"`
+	Slug                    string          `json:"slug"`
+	Name                    string          `json:"name"`
+	UserId                  int             `json:"user_id"`
+	Layout                  DashboardLayout `json:"layout"`
+	DashboardFiltersEnabled bool            `json:"dashboard_filters_enabled"`
+	Widgets                 []Widget        `json:"widgets"`
+	IsArchived              bool            `json:"is_archived"`
+	IsDraft                 bool            `json:"is_draft"`
+	CanEdit                 bool            `json:"can_edit"`
+	Tags                    []string        `json:"tags"`
+	IsFavorite              bool            `json:"is_favorite"`
+	PublicUrl               string          `json:"public_url"`
+	ApiKey                  string          `json:"api_key"`
+	Version                 int             `json:"version"`
+	UpdatedAt               string          `json:"updated_at"`
+	CreatedAt               string          `json:"created_at"`
+}
+
+// Wrap Redash paging response dashboard.
+type PagingResponseDashboard struct {
+	Count    int         `json:"count"`
+	Page     int         `json:"page"`
+	PageSize int         `json:"page_size"`
+	Results  []Dashboard `json:"results"`
+}
+
+// Wrap Redash new dashboard.
+type NewDashboard struct {
+	Name string `json:"name"`
+}
+
+// Wrap Redash dashboard update, zero(nil) fields are not updated.
+type DashboardUpdate struct {
+	Name                    string          `json:"name,omitempty"`
+	Layout                  DashboardLayout `json:"layout,omitempty"`
+	IsDraft                 *bool           `json:"is_draft,omitempty"`
+	DashboardFiltersEnabled *bool           `json:"dashboard_filters_enabled,omitempty"`
+	Tags                    []string        `json:"tags,omitempty"`
+	// Version is current version to detect conflict of update.
+	Version int `json:"version,omitempty"`
+}
+
+// Wrap Redash dashboard share.
+type DashboardShare struct {
+	PublicUrl string `json:"public_url"`
+	ApiKey    string `json:"api_key"`
+}
+
+// Wrap Redash api GET dashboards.
+func (ds DashboardsS) GetDashboards(pageSize, page int) (prd *PagingResponseDashboard, err error) {
+	return ds.GetDashboardsContext(context.Background(), pageSize, page)
+}
+
+// GetDashboardsContext is GetDashboards with context.
+func (ds DashboardsS) GetDashboardsContext(ctx context.Context, pageSize, page int) (prd *PagingResponseDashboard, err error) {
+	params := map[string]string{"page_size": strconv.Itoa(pageSize), "page": strconv.Itoa(page)}
+	prd = &PagingResponseDashboard{}
+	if err = doJSON(ctx, ds.Client, http.MethodGet, ds.Dashboards(""), params, nil, prd); err != nil {
+		return nil, err
+	}
+	return prd, nil
+}
+
+// Wrap Redash api GET dashboards with tags, dashboards having all of tags.
+func (ds DashboardsS) GetDashboardsByTags(pageSize, page int, tags ...string) (prd *PagingResponseDashboard, err error) {
+	return ds.GetDashboardsByTagsContext(context.Background(), pageSize, page, tags...)
+}
+
+// GetDashboardsByTagsContext is GetDashboardsByTags with context.
+func (ds DashboardsS) GetDashboardsByTagsContext(ctx context.Context, pageSize, page int, tags ...string) (prd *PagingResponseDashboard, err error) {
+	prd = &PagingResponseDashboard{}
+	if err = getValuesJSON(ctx, ds.Client, ds.Dashboards(""), pagingValues(pageSize, page, tags), prd); err != nil {
+		return nil, err
+	}
+	return prd, nil
+}
+
+// Wrap Redash api GET dashboards/tags.
+func (ds DashboardsS) GetTags() (tags []Tag, err error) {
+	return ds.GetTagsContext(context.Background())
+}
+
+// GetTagsContext is GetTags with context.
+func (ds DashboardsS) GetTagsContext(ctx context.Context) (tags []Tag, err error) {
+	var res struct {
+		Tags []Tag `json:"tags"`
+	}
+	if err = doJSON(ctx, ds.Client, http.MethodGet, ds.Dashboards("tags"), nil, nil, &res); err != nil {
+		return nil, err
+	}
+	return res.Tags, nil
+}
+
+// Wrap Redash api GET dashboards/${slug}.
+func (ds DashboardsS) GetDashboard(slug string) (d *Dashboard, err error) {
+	return ds.GetDashboardContext(context.Background(), slug)
+}
+
+// GetDashboardContext is GetDashboard with context.
+func (ds DashboardsS) GetDashboardContext(ctx context.Context, slug string) (d *Dashboard, err error) {
+	d = &Dashboard{}
+	if err = doJSON(ctx, ds.Client, http.MethodGet, ds.Dashboards(slug), nil, nil, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Wrap Redash api GET dashboards/${dashboard id}.
+func (ds DashboardsS) GetDashboardId(dashboardId int) (d *Dashboard, err error) {
+	return ds.GetDashboardIdContext(context.Background(), dashboardId)
+}
+
+// GetDashboardIdContext is GetDashboardId with context.
+func (ds DashboardsS) GetDashboardIdContext(ctx context.Context, dashboardId int) (d *Dashboard, err error) {
+	return ds.GetDashboardContext(ctx, strconv.Itoa(dashboardId))
+}
+
+// Wrap Redash api POST dashboards.
+func (ds DashboardsS) PostDashboard(newDashboard NewDashboard) (d *Dashboard, err error) {
+	return ds.PostDashboardContext(context.Background(), newDashboard)
+}
+
+// PostDashboardContext is PostDashboard with context.
+func (ds DashboardsS) PostDashboardContext(ctx context.Context, newDashboard NewDashboard) (d *Dashboard, err error) {
+	d = &Dashboard{}
+	if err = doJSON(ctx, ds.Client, http.MethodPost, ds.Dashboards(""), nil, newDashboard, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Wrap Redash api POST dashboards/${dashboard id}.
+func (ds DashboardsS) PostDashboardId(dashboardId int, update DashboardUpdate) (d *Dashboard, err error) {
+	return ds.PostDashboardIdContext(context.Background(), dashboardId, update)
+}
+
+// PostDashboardIdContext is PostDashboardId with context.
+func (ds DashboardsS) PostDashboardIdContext(ctx context.Context, dashboardId int, update DashboardUpdate) (d *Dashboard, err error) {
+	d = &Dashboard{}
+	if err = doJSON(ctx, ds.Client, http.MethodPost, ds.Dashboards(strconv.Itoa(dashboardId)), nil, update, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Wrap Redash api DELETE dashboards/${dashboard id}, which archives
+// the dashboard.
+func (ds DashboardsS) DeleteDashboard(dashboardId int) (err error) {
+	return ds.DeleteDashboardContext(context.Background(), dashboardId)
+}
+
+// DeleteDashboardContext is DeleteDashboard with context.
+func (ds DashboardsS) DeleteDashboardContext(ctx context.Context, dashboardId int) (err error) {
+	return doJSON(ctx, ds.Client, http.MethodDelete, ds.Dashboards(strconv.Itoa(dashboardId)), nil, nil, nil)
+}
+
+// Wrap Redash api POST fork.
+func (ds DashboardsS) PostFork(dashboardId int) (d *Dashboard, err error) {
+	return ds.PostForkContext(context.Background(), dashboardId)
+}
+
+// PostForkContext is PostFork with context.
+func (ds DashboardsS) PostForkContext(ctx context.Context, dashboardId int) (d *Dashboard, err error) {
+	d = &Dashboard{}
+	if err = doJSON(ctx, ds.Client, http.MethodPost, ds.Dashboards(fmt.Sprintf("%d/fork", dashboardId)), nil, nil, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Wrap Redash api POST share, which makes dashboard public.
+func (ds DashboardsS) PostShare(dashboardId int) (share *DashboardShare, err error) {
+	return ds.PostShareContext(context.Background(), dashboardId)
+}
+
+// PostShareContext is PostShare with context.
+func (ds DashboardsS) PostShareContext(ctx context.Context, dashboardId int) (share *DashboardShare, err error) {
+	share = &DashboardShare{}
+	if err = doJSON(ctx, ds.Client, http.MethodPost, ds.Dashboards(fmt.Sprintf("%d/share", dashboardId)), nil, nil, share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// Wrap Redash api DELETE share, which unshares public dashboard.
+func (ds DashboardsS) DeleteShare(dashboardId int) (err error) {
+	return ds.DeleteShareContext(context.Background(), dashboardId)
+}
+
+// DeleteShareContext is DeleteShare with context.
+func (ds DashboardsS) DeleteShareContext(ctx context.Context, dashboardId int) (err error) {
+	return doJSON(ctx, ds.Client, http.MethodDelete, ds.Dashboards(fmt.Sprintf("%d/share", dashboardId)), nil, nil, nil)
+}
+
+// Wrap Redash api POST favorite.
+func (ds DashboardsS) PostFavorite(dashboardId int) (err error) {
+	return ds.PostFavoriteContext(context.Background(), dashboardId)
+}
+
+// PostFavoriteContext is PostFavorite with context.
+func (ds DashboardsS) PostFavoriteContext(ctx context.Context, dashboardId int) (err error) {
+	return doJSON(ctx, ds.Client, http.MethodPost, ds.Dashboards(fmt.Sprintf("%d/favorite", dashboardId)), nil, nil, nil)
+}
+
+// Wrap Redash api DELETE favorite.
+func (ds DashboardsS) DeleteFavorite(dashboardId int) (err error) {
+	return ds.DeleteFavoriteContext(context.Background(), dashboardId)
+}
+
+// DeleteFavoriteContext is DeleteFavorite with context.
+func (ds DashboardsS) DeleteFavoriteContext(ctx context.Context, dashboardId int) (err error) {
+	return doJSON(ctx, ds.Client, http.MethodDelete, ds.Dashboards(fmt.Sprintf("%d/favorite", dashboardId)), nil, nil, nil)
+}
@@ -0,0 +1,150 @@
+package redash
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+const dashboardResp = `{
+  "id": 1,
+  "slug": "hello",
+  "name": "hello",
+  "user_id": 1,
+  "layout": "[[1, 2]]",
+  "dashboard_filters_enabled": false,
+  "widgets": [
+    {
+      "id": 1,
+      "dashboard_id": 1,
+      "width": 1,
+      "text": "",
+      "options": {"position": {"col": 0, "row": 0, "sizeX": 3, "sizeY": 8}},
+      "visualization": {"id": 1, "type": "TABLE", "name": "Table"}
+    }
+  ],
+  "is_archived": false,
+  "is_draft": true,
+  "can_edit": true,
+  "tags": ["sales"],
+  "is_favorite": true,
+  "version": 1,
+  "updated_at": "2017-07-16T10:52:26.541613+00:00",
+  "created_at": "2017-07-16T10:52:26.541613+00:00"
+}`
+
+var pagingDashboardResp = fmt.Sprintf(`{
+  "count": 1,
+  "page": 1,
+  "page_size": 20,
+  "results": [%s]
+}`, dashboardResp)
+
+func TestDashboardLayout(t *testing.T) {
+
+	for _, in := range []string{`"[[1, 2]]"`, `[[1, 2]]`} {
+		var layout DashboardLayout
+		if err := layout.UnmarshalJSON([]byte(in)); err != nil {
+			t.Fatal(err)
+		}
+		if want := (DashboardLayout{{1, 2}}); !reflect.DeepEqual(layout, want) {
+			t.Fatalf("Layout is not match,\n want: %v,\n have: %v\n", want, layout)
+		}
+	}
+}
+
+func TestDashboards(t *testing.T) {
+
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"GET /api/dashboards":               {body: pagingDashboardResp},
+		"POST /api/dashboards":              {body: dashboardResp},
+		"GET /api/dashboards/hello":         {body: dashboardResp},
+		"GET /api/dashboards/tags":          {body: `{"tags": [{"name": "sales", "count": 1}]}`},
+		"GET /api/dashboards/1":             {body: dashboardResp},
+		"POST /api/dashboards/1":            {body: dashboardResp},
+		"DELETE /api/dashboards/1":          {body: dashboardResp},
+		"POST /api/dashboards/1/fork":       {body: dashboardResp},
+		"POST /api/dashboards/1/share":      {body: `{"public_url": "http://localhost/public/dashboards/abc", "api_key": "abc"}`},
+		"DELETE /api/dashboards/1/share":    {body: `null`},
+		"POST /api/dashboards/1/favorite":   {body: `null`},
+		"DELETE /api/dashboards/1/favorite": {body: `null`},
+	})
+	ds := DashboardsS{client}
+
+	prd, err := ds.GetDashboards(20, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prd.Count != 1 || prd.Results[0].Slug != "hello" {
+		t.Fatalf("Dashboards is bad, have: %+v", prd)
+	}
+	d := &prd.Results[0]
+	if len(d.Widgets) != 1 || len(d.Layout) != 1 || !d.IsDraft || !d.IsFavorite || d.Tags[0] != "sales" {
+		t.Fatalf("Dashboard is bad, have: %+v", d)
+	}
+
+	if _, err = ds.GetDashboardsByTags(20, 1, "sales", "kpi"); err != nil {
+		t.Fatal(err)
+	}
+	if want := "page=1&page_size=20&tags=sales&tags=kpi"; bodies["GET /api/dashboards?"] != want {
+		t.Fatalf("Query is not match,\n want: %s,\n have: %s\n", want, bodies["GET /api/dashboards?"])
+	}
+	tags, err := ds.GetTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 1 || tags[0].Name != "sales" || tags[0].Count != 1 {
+		t.Fatalf("Tags is bad, have: %+v", tags)
+	}
+
+	if d, err = ds.GetDashboard("hello"); err != nil || d.Id != 1 {
+		t.Fatalf("GetDashboard is bad, have: %+v, %v", d, err)
+	}
+	if d, err = ds.GetDashboardId(1); err != nil || d.Name != "hello" {
+		t.Fatalf("GetDashboardId is bad, have: %+v, %v", d, err)
+	}
+
+	if _, err = ds.PostDashboard(NewDashboard{Name: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"hello"}`; bodies["POST /api/dashboards"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/dashboards"])
+	}
+
+	isDraft := false
+	if _, err = ds.PostDashboardId(1, DashboardUpdate{Name: "hello2", IsDraft: &isDraft, Tags: []string{"kpi"}, Version: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"hello2","is_draft":false,"tags":["kpi"],"version":1}`; bodies["POST /api/dashboards/1"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/dashboards/1"])
+	}
+
+	if err = ds.DeleteDashboard(1); err != nil {
+		t.Fatal(err)
+	}
+	if d, err = ds.PostFork(1); err != nil || d.Id != 1 {
+		t.Fatalf("PostFork is bad, have: %+v, %v", d, err)
+	}
+
+	share, err := ds.PostShare(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if share.ApiKey != "abc" {
+		t.Fatalf("Share is bad, have: %+v", share)
+	}
+	if err = ds.DeleteShare(1); err != nil {
+		t.Fatal(err)
+	}
+	if err = ds.PostFavorite(1); err != nil {
+		t.Fatal(err)
+	}
+	if err = ds.DeleteFavorite(1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = ds.GetDashboard("nothing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Error is not ErrNotFound, have: %v", err)
+	}
+}
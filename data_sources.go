@@ -0,0 +1,288 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default DataSources
+var DataSources = &DataSourcesS{DefaultClient}
+
+// interface of make DataSources endpoint.
+type DataSourceser interface {
+	DataSources(string) string
+}
+
+// Default struct for data sources.
+type DataSourcesS struct {
+	Client Interface
+}
+
+// Default implement of DataSources.
+func (dss DataSourcesS) DataSources(s string) (rs string) {
+	return "/api/data_sources/" + s
+}
+
+// Wrap Redash data source.
+type DataSource struct {
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Syntax      string `json:"syntax"`
+	Paused      int    `json:"paused"`
+	PauseReason string `json:"pause_reason"`
+	ViewOnly    bool   `json:"view_only"`
+	// Options is connection options, only in GetDataSource.
+	Options            map[string]interface{} `json:"options"`
+	QueueName          string                 `json:"queue_name"`
+	ScheduledQueueName string                 `json:"scheduled_queue_name"`
+}
+
+// Wrap Redash new data source. It is also used to update.
+type NewDataSource struct {
+	Name    string                 `json:"name"`
+	Type    string                 `json:"type"`
+	Options map[string]interface{} `json:"options"`
+}
+
+// Wrap Redash schema table.
+type SchemaTable struct {
+	Name    string         `json:"name"`
+	Columns []SchemaColumn `json:"columns"`
+}
+
+// Wrap Redash schema column. Type is empty if data source does not
+// provide it.
+type SchemaColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// UnmarshalJSON accept column as name string(old versions) or object.
+func (sc *SchemaColumn) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*sc = SchemaColumn{Name: name}
+		return nil
+	}
+	type schemaColumn SchemaColumn
+	var c schemaColumn
+	if err := json.Unmarshal(b, &c); err != nil {
+		return err
+	}
+	*sc = SchemaColumn(c)
+	return nil
+}
+
+// Wrap Redash data source connection test result.
+type DataSourceTest struct {
+	Ok      bool   `json:"ok"`
+	Message string `json:"message"`
+}
+
+// Wrap Redash api GET data_sources.
+func (dss DataSourcesS) GetDataSources() (dataSources []DataSource, err error) {
+	return dss.GetDataSourcesContext(context.Background())
+}
+
+// GetDataSourcesContext is GetDataSources with context.
+func (dss DataSourcesS) GetDataSourcesContext(ctx context.Context) (dataSources []DataSource, err error) {
+	if err = doJSON(ctx, dss.Client, http.MethodGet, dss.DataSources(""), nil, nil, &dataSources); err != nil {
+		return nil, err
+	}
+	return dataSources, nil
+}
+
+// Wrap Redash api GET data_sources/${data source id}.
+func (dss DataSourcesS) GetDataSource(dataSourceId int) (ds *DataSource, err error) {
+	return dss.GetDataSourceContext(context.Background(), dataSourceId)
+}
+
+// GetDataSourceContext is GetDataSource with context.
+func (dss DataSourcesS) GetDataSourceContext(ctx context.Context, dataSourceId int) (ds *DataSource, err error) {
+	ds = &DataSource{}
+	if err = doJSON(ctx, dss.Client, http.MethodGet, dss.DataSources(strconv.Itoa(dataSourceId)), nil, nil, ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// GetDataSourceByName find data source by name from GetDataSources.
+// If it is not found, error is ErrNotFound.
+func (dss DataSourcesS) GetDataSourceByName(name string) (ds *DataSource, err error) {
+	return dss.GetDataSourceByNameContext(context.Background(), name)
+}
+
+// GetDataSourceByNameContext is GetDataSourceByName with context.
+func (dss DataSourcesS) GetDataSourceByNameContext(ctx context.Context, name string) (ds *DataSource, err error) {
+	dataSources, err := dss.GetDataSourcesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range dataSources {
+		if dataSources[i].Name == name {
+			return &dataSources[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: data source %q", ErrNotFound, name)
+}
+
+// Wrap Redash api POST data_sources.
+func (dss DataSourcesS) PostDataSource(newDataSource NewDataSource) (ds *DataSource, err error) {
+	return dss.PostDataSourceContext(context.Background(), newDataSource)
+}
+
+// PostDataSourceContext is PostDataSource with context.
+func (dss DataSourcesS) PostDataSourceContext(ctx context.Context, newDataSource NewDataSource) (ds *DataSource, err error) {
+	ds = &DataSource{}
+	if err = doJSON(ctx, dss.Client, http.MethodPost, dss.DataSources(""), nil, newDataSource, ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// Wrap Redash api POST data_sources/${data source id}.
+func (dss DataSourcesS) PostDataSourceId(dataSourceId int, update NewDataSource) (ds *DataSource, err error) {
+	return dss.PostDataSourceIdContext(context.Background(), dataSourceId, update)
+}
+
+// PostDataSourceIdContext is PostDataSourceId with context.
+func (dss DataSourcesS) PostDataSourceIdContext(ctx context.Context, dataSourceId int, update NewDataSource) (ds *DataSource, err error) {
+	ds = &DataSource{}
+	if err = doJSON(ctx, dss.Client, http.MethodPost, dss.DataSources(strconv.Itoa(dataSourceId)), nil, update, ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// Wrap Redash api DELETE data_sources/${data source id}.
+func (dss DataSourcesS) DeleteDataSource(dataSourceId int) (err error) {
+	return dss.DeleteDataSourceContext(context.Background(), dataSourceId)
+}
+
+// DeleteDataSourceContext is DeleteDataSource with context.
+func (dss DataSourcesS) DeleteDataSourceContext(ctx context.Context, dataSourceId int) (err error) {
+	return doJSON(ctx, dss.Client, http.MethodDelete, dss.DataSources(strconv.Itoa(dataSourceId)), nil, nil, nil)
+}
+
+// schemaJob is job of getting schema, which Redash 10 or later returns
+// when schema is not cached or refresh is requested.
+type schemaJob struct {
+	Id     string        `json:"id"`
+	Status int           `json:"status"`
+	Error  string        `json:"error"`
+	Result []SchemaTable `json:"result"`
+}
+
+// Wrap Redash api GET schema. If refresh is true, Redash refreshes
+// cached schema. If Redash answers job, it is polled until finished
+// with DefaultExecuteOptions.Backoff.
+func (dss DataSourcesS) GetSchema(dataSourceId int, refresh bool) (tables []SchemaTable, err error) {
+	return dss.GetSchemaContext(context.Background(), dataSourceId, refresh)
+}
+
+// GetSchemaContext is GetSchema with context.
+func (dss DataSourcesS) GetSchemaContext(ctx context.Context, dataSourceId int, refresh bool) (tables []SchemaTable, err error) {
+	var params map[string]string
+	if refresh {
+		params = map[string]string{"refresh": "true"}
+	}
+	var schema struct {
+		Schema []SchemaTable `json:"schema"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		Job *schemaJob `json:"job"`
+	}
+	if err = doJSON(ctx, dss.Client, http.MethodGet, dss.DataSources(fmt.Sprintf("%d/schema", dataSourceId)), params, nil, &schema); err != nil {
+		return nil, err
+	}
+	if schema.Error != nil {
+		return nil, errors.New("redash: failed to get schema: " + schema.Error.Message)
+	}
+	if schema.Job != nil {
+		return dss.waitSchema(ctx, *schema.Job, DefaultExecuteOptions.Backoff)
+	}
+	return schema.Schema, nil
+}
+
+// waitSchema polls schema job until it is finished.
+func (dss DataSourcesS) waitSchema(ctx context.Context, job schemaJob, backoff Backoff) (tables []SchemaTable, err error) {
+	for attempt := 0; ; attempt++ {
+		switch job.Status {
+		case JobSuccess:
+			return job.Result, nil
+		case JobFailure, JobCancelled:
+			return nil, &JobError{Job: JobInner{Id: job.Id, Status: job.Status, Error: job.Error}}
+		}
+		timer := time.NewTimer(backoff.Duration(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		var j struct {
+			Job schemaJob `json:"job"`
+		}
+		if err = doJSON(ctx, dss.Client, http.MethodGet, "/api/jobs/"+job.Id, nil, nil, &j); err != nil {
+			return nil, err
+		}
+		job = j.Job
+	}
+}
+
+// Wrap Redash api POST test, which tests connection of data source.
+// Failure of connection is reported as Ok false, not error.
+func (dss DataSourcesS) PostTest(dataSourceId int) (test *DataSourceTest, err error) {
+	return dss.PostTestContext(context.Background(), dataSourceId)
+}
+
+// PostTestContext is PostTest with context.
+func (dss DataSourcesS) PostTestContext(ctx context.Context, dataSourceId int) (test *DataSourceTest, err error) {
+	test = &DataSourceTest{}
+	if err = doJSON(ctx, dss.Client, http.MethodPost, dss.DataSources(fmt.Sprintf("%d/test", dataSourceId)), nil, nil, test); err != nil {
+		return nil, err
+	}
+	return test, nil
+}
+
+// Wrap Redash api POST pause, which pauses data source with reason.
+func (dss DataSourcesS) PostPause(dataSourceId int, reason string) (ds *DataSource, err error) {
+	return dss.PostPauseContext(context.Background(), dataSourceId, reason)
+}
+
+// PostPauseContext is PostPause with context.
+func (dss DataSourcesS) PostPauseContext(ctx context.Context, dataSourceId int, reason string) (ds *DataSource, err error) {
+	body := struct {
+		Reason string `json:"reason,omitempty"`
+	}{reason}
+	ds = &DataSource{}
+	if err = doJSON(ctx, dss.Client, http.MethodPost, dss.DataSources(fmt.Sprintf("%d/pause", dataSourceId)), nil, body, ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// Wrap Redash api DELETE pause, which resumes data source.
+func (dss DataSourcesS) DeletePause(dataSourceId int) (ds *DataSource, err error) {
+	return dss.DeletePauseContext(context.Background(), dataSourceId)
+}
+
+// DeletePauseContext is DeletePause with context.
+func (dss DataSourcesS) DeletePauseContext(ctx context.Context, dataSourceId int) (ds *DataSource, err error) {
+	ds = &DataSource{}
+	if err = doJSON(ctx, dss.Client, http.MethodDelete, dss.DataSources(fmt.Sprintf("%d/pause", dataSourceId)), nil, nil, ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
@@ -0,0 +1,130 @@
+package redash
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const dataSourceResp = `{
+  "id": 1,
+  "name": "pg",
+  "type": "pg",
+  "syntax": "sql",
+  "paused": 0,
+  "pause_reason": null,
+  "view_only": false,
+  "options": {"dbname": "redash", "host": "localhost"},
+  "queue_name": "queries",
+  "scheduled_queue_name": "scheduled_queries"
+}`
+
+const pausedDataSourceResp = `{"id": 1, "name": "pg", "type": "pg", "paused": 1, "pause_reason": "maintenance"}`
+
+const schemaResp = `{
+  "schema": [
+    {"name": "hello", "columns": ["id", "name"]},
+    {"name": "world", "columns": [{"name": "id", "type": "integer"}]}
+  ]
+}`
+
+func TestDataSources(t *testing.T) {
+
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"GET /api/data_sources":            {body: `[{"id": 2, "name": "mysql", "type": "mysql"}, ` + dataSourceResp + `]`},
+		"POST /api/data_sources":           {body: dataSourceResp},
+		"GET /api/data_sources/1":          {body: dataSourceResp},
+		"POST /api/data_sources/1":         {body: dataSourceResp},
+		"DELETE /api/data_sources/1":       {status: 204},
+		"GET /api/data_sources/1/schema":   {body: schemaResp},
+		"GET /api/data_sources/2/schema":   {body: `{"error": {"code": 1, "message": "not supported"}}`},
+		"GET /api/data_sources/3/schema":   {body: `{"job": {"id": "s3", "status": 1, "error": "", "result": null}}`},
+		"GET /api/jobs/s3":                 {body: `{"job": {"id": "s3", "status": 3, "error": "", "result": [{"name": "hello", "columns": ["id"]}]}}`},
+		"GET /api/data_sources/4/schema":   {body: `{"job": {"id": "s4", "status": 4, "error": "Error retrieving schema", "result": null}}`},
+		"POST /api/data_sources/1/test":    {body: `{"message": "connection refused", "ok": false}`},
+		"POST /api/data_sources/1/pause":   {body: pausedDataSourceResp},
+		"DELETE /api/data_sources/1/pause": {body: dataSourceResp},
+	})
+	dss := DataSourcesS{client}
+
+	dataSources, err := dss.GetDataSources()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dataSources) != 2 {
+		t.Fatalf("DataSources num is bad,\n want: %d,\n have: %d\n", 2, len(dataSources))
+	}
+
+	ds, err := dss.GetDataSourceByName("pg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ds.Id != 1 {
+		t.Fatalf("DataSource id is not match,\n want: %d,\n have: %d\n", 1, ds.Id)
+	}
+	if _, err = dss.GetDataSourceByName("nothing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Error is not ErrNotFound, have: %v", err)
+	}
+
+	if ds, err = dss.GetDataSource(1); err != nil || ds.Options["host"] != "localhost" {
+		t.Fatalf("GetDataSource is bad, have: %+v, %v", ds, err)
+	}
+
+	newDataSource := NewDataSource{Name: "pg", Type: "pg", Options: map[string]interface{}{"dbname": "redash"}}
+	if _, err = dss.PostDataSource(newDataSource); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"pg","type":"pg","options":{"dbname":"redash"}}`; bodies["POST /api/data_sources"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/data_sources"])
+	}
+	if _, err = dss.PostDataSourceId(1, newDataSource); err != nil {
+		t.Fatal(err)
+	}
+	if err = dss.DeleteDataSource(1); err != nil {
+		t.Fatal(err)
+	}
+
+	tables, err := dss.GetSchema(1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SchemaTable{
+		{"hello", []SchemaColumn{{Name: "id"}, {Name: "name"}}},
+		{"world", []SchemaColumn{{Name: "id", Type: "integer"}}},
+	}
+	if !reflect.DeepEqual(tables, want) {
+		t.Fatalf("Schema is not match,\n want: %v,\n have: %v\n", want, tables)
+	}
+	if _, err = dss.GetSchema(2, false); err == nil {
+		t.Fatal("Schema error should be error")
+	}
+	// Redash 10 or later answers job when schema is not cached.
+	if tables, err = dss.GetSchema(3, false); err != nil {
+		t.Fatal(err)
+	}
+	if want := []SchemaTable{{"hello", []SchemaColumn{{Name: "id"}}}}; !reflect.DeepEqual(tables, want) {
+		t.Fatalf("Schema is not match,\n want: %v,\n have: %v\n", want, tables)
+	}
+	var je *JobError
+	if _, err = dss.GetSchema(4, false); !errors.As(err, &je) || je.Job.Error != "Error retrieving schema" {
+		t.Fatalf("Error is not JobError, have: %v", err)
+	}
+
+	test, err := dss.PostTest(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if test.Ok || test.Message != "connection refused" {
+		t.Fatalf("Test is bad, have: %+v", test)
+	}
+
+	if ds, err = dss.PostPause(1, "maintenance"); err != nil || ds.Paused != 1 {
+		t.Fatalf("PostPause is bad, have: %+v, %v", ds, err)
+	}
+	if want := `{"reason":"maintenance"}`; bodies["POST /api/data_sources/1/pause"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/data_sources/1/pause"])
+	}
+	if ds, err = dss.DeletePause(1); err != nil || ds.Paused != 0 {
+		t.Fatalf("DeletePause is bad, have: %+v, %v", ds, err)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// Redash destination types, see Destination.Type.
+const (
+	DestinationSlack     = "slack"
+	DestinationEmail     = "email"
+	DestinationWebhook   = "webhook"
+	DestinationPagerDuty = "pagerduty"
+)
+
+// Default Destinations
+var Destinations = &DestinationsS{DefaultClient}
+
+// interface of make Destinations endpoint.
+type Destinationser interface {
+	Destinations(string) string
+}
+
+// Default struct for destinations.
+type DestinationsS struct {
+	Client Interface
+}
+
+// Default implement of Destinations.
+func (ds DestinationsS) Destinations(s string) (rs string) {
+	return "/api/destinations/" + s
+}
+
+// Wrap Redash alert destination.
+type Destination struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Icon string `json:"icon"`
+	// Options depends on Type, decode it by DecodeOptions.
+	// It is only in GetDestination.
+	Options json.RawMessage `json:"options"`
+}
+
+// DecodeOptions decode Options into v, like *SlackOptions for slack.
+func (d Destination) DecodeOptions(options interface{}) error {
+	if len(d.Options) == 0 {
+		return nil
+	}
+	return json.Unmarshal(d.Options, options)
+}
+
+// Wrap Redash destination type, ConfigurationSchema is json schema of
+// options.
+type DestinationTypeInfo struct {
+	Name                string          `json:"name"`
+	Type                string          `json:"type"`
+	Icon                string          `json:"icon"`
+	ConfigurationSchema json.RawMessage `json:"configuration_schema"`
+}
+
+// DestinationOptions is typed options of destination type.
+type DestinationOptions interface {
+	DestinationType() string
+}
+
+// Wrap Redash new destination. It is also used to update.
+// If Type is empty and Options is DestinationOptions, Type of Options
+// is used.
+type NewDestination struct {
+	Name    string      `json:"name"`
+	Type    string      `json:"type"`
+	Options interface{} `json:"options"`
+}
+
+// Wrap Redash slack destination options.
+type SlackOptions struct {
+	Url       string `json:"url"`
+	Username  string `json:"username,omitempty"`
+	IconEmoji string `json:"icon_emoji,omitempty"`
+	IconUrl   string `json:"icon_url,omitempty"`
+	Channel   string `json:"channel,omitempty"`
+}
+
+func (SlackOptions) DestinationType() string { return DestinationSlack }
+
+// Wrap Redash email destination options, Addresses is comma separated.
+type EmailOptions struct {
+	Addresses       string `json:"addresses"`
+	SubjectTemplate string `json:"subject_template,omitempty"`
+}
+
+func (EmailOptions) DestinationType() string { return DestinationEmail }
+
+// Wrap Redash webhook destination options.
+type WebhookOptions struct {
+	Url      string `json:"url"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+func (WebhookOptions) DestinationType() string { return DestinationWebhook }
+
+// Wrap Redash PagerDuty destination options.
+type PagerDutyOptions struct {
+	IntegrationKey string `json:"integration_key"`
+	Description    string `json:"description,omitempty"`
+}
+
+func (PagerDutyOptions) DestinationType() string { return DestinationPagerDuty }
+
+// withType fill Type from Options.
+func (nd NewDestination) withType() NewDestination {
+	if o, ok := nd.Options.(DestinationOptions); ok && nd.Type == "" {
+		nd.Type = o.DestinationType()
+	}
+	return nd
+}
+
+// Wrap Redash api GET destinations.
+func (ds DestinationsS) GetDestinations() (destinations []Destination, err error) {
+	return ds.GetDestinationsContext(context.Background())
+}
+
+// GetDestinationsContext is GetDestinations with context.
+func (ds DestinationsS) GetDestinationsContext(ctx context.Context) (destinations []Destination, err error) {
+	if err = doJSON(ctx, ds.Client, http.MethodGet, ds.Destinations(""), nil, nil, &destinations); err != nil {
+		return nil, err
+	}
+	return destinations, nil
+}
+
+// Wrap Redash api GET destinations/types.
+func (ds DestinationsS) GetTypes() (types []DestinationTypeInfo, err error) {
+	return ds.GetTypesContext(context.Background())
+}
+
+// GetTypesContext is GetTypes with context.
+func (ds DestinationsS) GetTypesContext(ctx context.Context) (types []DestinationTypeInfo, err error) {
+	if err = doJSON(ctx, ds.Client, http.MethodGet, ds.Destinations("types"), nil, nil, &types); err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+// Wrap Redash api GET destinations/${destination id}.
+func (ds DestinationsS) GetDestination(destinationId int) (d *Destination, err error) {
+	return ds.GetDestinationContext(context.Background(), destinationId)
+}
+
+// GetDestinationContext is GetDestination with context.
+func (ds DestinationsS) GetDestinationContext(ctx context.Context, destinationId int) (d *Destination, err error) {
+	d = &Destination{}
+	if err = doJSON(ctx, ds.Client, http.MethodGet, ds.Destinations(strconv.Itoa(destinationId)), nil, nil, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Wrap Redash api POST destinations.
+func (ds DestinationsS) PostDestination(newDestination NewDestination) (d *Destination, err error) {
+	return ds.PostDestinationContext(context.Background(), newDestination)
+}
+
+// PostDestinationContext is PostDestination with context.
+func (ds DestinationsS) PostDestinationContext(ctx context.Context, newDestination NewDestination) (d *Destination, err error) {
+	d = &Destination{}
+	if err = doJSON(ctx, ds.Client, http.MethodPost, ds.Destinations(""), nil, newDestination.withType(), d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Wrap Redash api POST destinations/${destination id}.
+func (ds DestinationsS) PostDestinationId(destinationId int, update NewDestination) (d *Destination, err error) {
+	return ds.PostDestinationIdContext(context.Background(), destinationId, update)
+}
+
+// PostDestinationIdContext is PostDestinationId with context.
+func (ds DestinationsS) PostDestinationIdContext(ctx context.Context, destinationId int, update NewDestination) (d *Destination, err error) {
+	d = &Destination{}
+	if err = doJSON(ctx, ds.Client, http.MethodPost, ds.Destinations(strconv.Itoa(destinationId)), nil, update.withType(), d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Wrap Redash api DELETE destinations/${destination id}.
+func (ds DestinationsS) DeleteDestination(destinationId int) (err error) {
+	return ds.DeleteDestinationContext(context.Background(), destinationId)
+}
+
+// DeleteDestinationContext is DeleteDestination with context.
+func (ds DestinationsS) DeleteDestinationContext(ctx context.Context, destinationId int) (err error) {
+	return doJSON(ctx, ds.Client, http.MethodDelete, ds.Destinations(strconv.Itoa(destinationId)), nil, nil, nil)
+}
@@ -0,0 +1,99 @@
+package redash
+
+import (
+	"errors"
+	"testing"
+)
+
+const destinationResp = `{
+  "id": 2,
+  "name": "alerts",
+  "type": "slack",
+  "icon": "fa-slack",
+  "options": {"url": "https://hooks.slack.com/services/x", "channel": "#alerts"}
+}`
+
+const destinationTypesResp = `[
+  {"name": "Slack", "type": "slack", "icon": "fa-slack", "configuration_schema": {"type": "object"}},
+  {"name": "Email", "type": "email", "icon": "fa-envelope", "configuration_schema": {"type": "object"}}
+]`
+
+func TestDestinations(t *testing.T) {
+
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"GET /api/destinations":       {body: "[" + destinationResp + "]"},
+		"GET /api/destinations/types": {body: destinationTypesResp},
+		"GET /api/destinations/2":     {body: destinationResp},
+		"POST /api/destinations":      {body: destinationResp},
+		"POST /api/destinations/2":    {body: destinationResp},
+		"DELETE /api/destinations/2":  {body: `null`},
+	})
+	ds := DestinationsS{client}
+
+	destinations, err := ds.GetDestinations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(destinations) != 1 || destinations[0].Type != DestinationSlack {
+		t.Fatalf("Destinations is bad, have: %+v", destinations)
+	}
+
+	types, err := ds.GetTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(types) != 2 || types[1].Type != DestinationEmail || string(types[0].ConfigurationSchema) != `{"type": "object"}` {
+		t.Fatalf("Types is bad, have: %+v", types)
+	}
+
+	d, err := ds.GetDestination(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var so SlackOptions
+	if err = d.DecodeOptions(&so); err != nil {
+		t.Fatal(err)
+	}
+	if so.Url != "https://hooks.slack.com/services/x" || so.Channel != "#alerts" {
+		t.Fatalf("SlackOptions is bad, have: %+v", so)
+	}
+
+	if _, err = ds.PostDestination(NewDestination{Name: "alerts", Options: SlackOptions{Url: "https://hooks.slack.com/services/x"}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"alerts","type":"slack","options":{"url":"https://hooks.slack.com/services/x"}}`; bodies["POST /api/destinations"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/destinations"])
+	}
+	if _, err = ds.PostDestinationId(2, NewDestination{Name: "pd", Options: PagerDutyOptions{IntegrationKey: "abc"}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"pd","type":"pagerduty","options":{"integration_key":"abc"}}`; bodies["POST /api/destinations/2"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/destinations/2"])
+	}
+
+	if err = ds.DeleteDestination(2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ds.GetDestination(3); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("want ErrNotFound, have: %v", err)
+	}
+}
+
+func TestNewDestinationType(t *testing.T) {
+	cases := []struct {
+		nd   NewDestination
+		want string
+	}{
+		{NewDestination{Options: SlackOptions{}}, DestinationSlack},
+		{NewDestination{Options: EmailOptions{}}, DestinationEmail},
+		{NewDestination{Options: WebhookOptions{}}, DestinationWebhook},
+		{NewDestination{Options: PagerDutyOptions{}}, DestinationPagerDuty},
+		{NewDestination{Type: "hipchat", Options: SlackOptions{}}, "hipchat"},
+		{NewDestination{Options: map[string]string{}}, ""},
+	}
+	for _, c := range cases {
+		if have := c.nd.withType().Type; have != c.want {
+			t.Errorf("want: %s, have: %s", c.want, have)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+)
+
+// Format is file format of query result download.
+type Format string
+
+// Redash query result formats.
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatTSV  Format = "tsv"
+	FormatXLSX Format = "xlsx"
+)
+
+// valid check f is one of known formats.
+func (f Format) valid() error {
+	switch f {
+	case FormatJSON, FormatCSV, FormatTSV, FormatXLSX:
+		return nil
+	}
+	return fmt.Errorf("redash: unknown format %q", string(f))
+}
+
+// Progress is called after each write of download with bytes written
+// so far and total bytes, total is -1 if unknown.
+type Progress func(written, total int64)
+
+// progressWriter call progress on each write.
+type progressWriter struct {
+	w        io.Writer
+	written  int64
+	total    int64
+	progress Progress
+}
+
+func (pw *progressWriter) Write(p []byte) (n int, err error) {
+	n, err = pw.w.Write(p)
+	pw.written += int64(n)
+	pw.progress(pw.written, pw.total)
+	return n, err
+}
+
+// RowReader read records of csv or tsv result one by one, without
+// buffering whole result. First line is read as header on creation.
+type RowReader struct {
+	r       io.Reader
+	cr      *csv.Reader
+	columns []string
+}
+
+// NewRowReader make RowReader of r in format, which is FormatCSV or
+// FormatTSV.
+func NewRowReader(r io.Reader, format Format) (rr *RowReader, err error) {
+	cr := csv.NewReader(r)
+	switch format {
+	case FormatCSV:
+	case FormatTSV:
+		cr.Comma = '\t'
+	default:
+		return nil, fmt.Errorf("redash: row reader does not support format %q", string(format))
+	}
+	cr.LazyQuotes = true
+	columns, err := cr.Read()
+	if err == io.EOF {
+		// empty result has no header.
+		return &RowReader{r: r, cr: cr}, nil
+	}
+	if err != nil {
+		return nil, &DecodeError{Type: "RowReader", Err: err}
+	}
+	return &RowReader{r: r, cr: cr, columns: columns}, nil
+}
+
+// Columns return column names of header.
+func (rr *RowReader) Columns() []string {
+	return rr.columns
+}
+
+// Read return next record, or io.EOF if no more record.
+func (rr *RowReader) Read() (record []string, err error) {
+	if rr.columns == nil {
+		return nil, io.EOF
+	}
+	record, err = rr.cr.Read()
+	if err != nil && err != io.EOF {
+		return nil, &DecodeError{Type: "RowReader", Err: err}
+	}
+	return record, err
+}
+
+// Rows iterate all remaining records, error is yielded once and stops.
+func (rr *RowReader) Rows() iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		for {
+			record, err := rr.Read()
+			if err == io.EOF {
+				return
+			}
+			if !yield(record, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Close close underlying reader if it is io.Closer.
+func (rr *RowReader) Close() error {
+	if c, ok := rr.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// streamKey is context key to mark request whose body is streamed.
+type streamKey struct{}
+
+// withStream mark ctx that response body is streamed. Timeout of
+// http.Client covers reading whole body and would cut large result
+// off, so it is not applied and ctx bounds the request instead.
+func withStream(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamKey{}, true)
+}
+
+// isStream report whether ctx is marked by withStream.
+func isStream(ctx context.Context) bool {
+	stream, _ := ctx.Value(streamKey{}).(bool)
+	return stream
+}
+
+// getResults GET result file and return response to stream.
+func (qs QueriesS) getResults(ctx context.Context, sub string, format Format) (resp *http.Response, err error) {
+	if err = format.valid(); err != nil {
+		return nil, err
+	}
+	return GetInterContext(withStream(ctx), qs.Client, qs.Queries(sub+"."+string(format)), nil)
+}
+
+// download copy result file to w with progress.
+func (qs QueriesS) download(ctx context.Context, w io.Writer, sub string, format Format, progress Progress) (n int64, err error) {
+	resp, err := qs.getResults(ctx, sub, format)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if progress != nil {
+		w = &progressWriter{w: w, total: resp.ContentLength, progress: progress}
+	}
+	return io.Copy(w, resp.Body)
+}
+
+// GetResultsByIdFormat is GetResultsById with typed format, body is
+// returned to stream and must be closed.
+func (qs QueriesS) GetResultsByIdFormat(queryId, queryResultId int, format Format) (rc io.ReadCloser, err error) {
+	return qs.GetResultsByIdFormatContext(context.Background(), queryId, queryResultId, format)
+}
+
+// GetResultsByIdFormatContext is GetResultsByIdFormat with context.
+func (qs QueriesS) GetResultsByIdFormatContext(ctx context.Context, queryId, queryResultId int, format Format) (rc io.ReadCloser, err error) {
+	resp, err := qs.getResults(ctx, fmt.Sprintf("%d/results/%d", queryId, queryResultId), format)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetResultsByQueryIdFormat is GetResultsByQueryId with typed format,
+// body is returned to stream and must be closed.
+func (qs QueriesS) GetResultsByQueryIdFormat(queryId int, format Format) (rc io.ReadCloser, err error) {
+	return qs.GetResultsByQueryIdFormatContext(context.Background(), queryId, format)
+}
+
+// GetResultsByQueryIdFormatContext is GetResultsByQueryIdFormat with context.
+func (qs QueriesS) GetResultsByQueryIdFormatContext(ctx context.Context, queryId int, format Format) (rc io.ReadCloser, err error) {
+	resp, err := qs.getResults(ctx, fmt.Sprintf("%d/results", queryId), format)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetRowReaderById stream records of query result in format(FormatCSV
+// or FormatTSV). RowReader must be closed.
+func (qs QueriesS) GetRowReaderById(queryId, queryResultId int, format Format) (rr *RowReader, err error) {
+	return qs.GetRowReaderByIdContext(context.Background(), queryId, queryResultId, format)
+}
+
+// GetRowReaderByIdContext is GetRowReaderById with context.
+func (qs QueriesS) GetRowReaderByIdContext(ctx context.Context, queryId, queryResultId int, format Format) (rr *RowReader, err error) {
+	rc, err := qs.GetResultsByIdFormatContext(ctx, queryId, queryResultId, format)
+	if err != nil {
+		return nil, err
+	}
+	if rr, err = NewRowReader(rc, format); err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return rr, nil
+}
+
+// GetRowReaderByQueryId stream records of latest result of query in
+// format(FormatCSV or FormatTSV). RowReader must be closed.
+func (qs QueriesS) GetRowReaderByQueryId(queryId int, format Format) (rr *RowReader, err error) {
+	return qs.GetRowReaderByQueryIdContext(context.Background(), queryId, format)
+}
+
+// GetRowReaderByQueryIdContext is GetRowReaderByQueryId with context.
+func (qs QueriesS) GetRowReaderByQueryIdContext(ctx context.Context, queryId int, format Format) (rr *RowReader, err error) {
+	rc, err := qs.GetResultsByQueryIdFormatContext(ctx, queryId, format)
+	if err != nil {
+		return nil, err
+	}
+	if rr, err = NewRowReader(rc, format); err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return rr, nil
+}
+
+// DownloadById write query result file in format to w, and return
+// bytes written. progress is optional(nil).
+func (qs QueriesS) DownloadById(w io.Writer, queryId, queryResultId int, format Format, progress Progress) (n int64, err error) {
+	return qs.DownloadByIdContext(context.Background(), w, queryId, queryResultId, format, progress)
+}
+
+// DownloadByIdContext is DownloadById with context.
+func (qs QueriesS) DownloadByIdContext(ctx context.Context, w io.Writer, queryId, queryResultId int, format Format, progress Progress) (n int64, err error) {
+	return qs.download(ctx, w, fmt.Sprintf("%d/results/%d", queryId, queryResultId), format, progress)
+}
+
+// DownloadByQueryId write latest result file of query in format to w,
+// and return bytes written. progress is optional(nil).
+func (qs QueriesS) DownloadByQueryId(w io.Writer, queryId int, format Format, progress Progress) (n int64, err error) {
+	return qs.DownloadByQueryIdContext(context.Background(), w, queryId, format, progress)
+}
+
+// DownloadByQueryIdContext is DownloadByQueryId with context.
+func (qs QueriesS) DownloadByQueryIdContext(ctx context.Context, w io.Writer, queryId int, format Format, progress Progress) (n int64, err error) {
+	return qs.download(ctx, w, fmt.Sprintf("%d/results", queryId), format, progress)
+}
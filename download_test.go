@@ -0,0 +1,145 @@
+package redash
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const csvResp = "id,name\n1,\"hello, world\"\n2,\"say \"\"hi\"\"\"\n"
+
+func TestRowReader(t *testing.T) {
+	cases := []struct {
+		format Format
+		body   string
+	}{
+		{FormatCSV, csvResp},
+		{FormatTSV, "id\tname\n1\thello, world\n2\tsay \"hi\"\n"},
+	}
+	for _, c := range cases {
+		rr, err := NewRowReader(strings.NewReader(c.body), c.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cols := rr.Columns(); len(cols) != 2 || cols[1] != "name" {
+			t.Fatalf("Columns is bad, have: %v", cols)
+		}
+		var records [][]string
+		for record, err := range rr.Rows() {
+			if err != nil {
+				t.Fatal(err)
+			}
+			records = append(records, record)
+		}
+		if len(records) != 2 || records[0][1] != "hello, world" || records[1][1] != `say "hi"` {
+			t.Fatalf("Records of %s is bad, have: %q", c.format, records)
+		}
+		if _, err = rr.Read(); err != io.EOF {
+			t.Fatalf("Error is not EOF, have: %v", err)
+		}
+	}
+
+	rr, err := NewRowReader(strings.NewReader(""), FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = rr.Read(); err != io.EOF {
+		t.Fatalf("Error is not EOF, have: %v", err)
+	}
+	if _, err = NewRowReader(strings.NewReader("{}"), FormatJSON); err == nil {
+		t.Fatal("json is not supported by RowReader")
+	}
+	rr, _ = NewRowReader(strings.NewReader("a,b\n1,2,3\n"), FormatCSV)
+	var de *DecodeError
+	if _, err = rr.Read(); !errors.As(err, &de) {
+		t.Fatalf("Error is not DecodeError, have: %v", err)
+	}
+}
+
+func TestDownload(t *testing.T) {
+
+	client, _ := newMockServer(t, map[string]mockRoute{
+		"GET /api/queries/1/results/2.csv": {body: csvResp},
+		"GET /api/queries/1/results.tsv":   {body: "id\n1\n"},
+	})
+	qs := QueriesS{client}
+
+	var buf bytes.Buffer
+	var written, total int64
+	n, err := qs.DownloadById(&buf, 1, 2, FormatCSV, func(w, all int64) {
+		written, total = w, all
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != csvResp || n != int64(len(csvResp)) {
+		t.Fatalf("Download is bad, have: %d, %q", n, buf.String())
+	}
+	if written != n || total != n {
+		t.Fatalf("Progress is bad, have: %d/%d", written, total)
+	}
+
+	buf.Reset()
+	if _, err = qs.DownloadByQueryId(&buf, 1, FormatTSV, nil); err != nil || buf.String() != "id\n1\n" {
+		t.Fatalf("Download is bad, have: %q, %v", buf.String(), err)
+	}
+	if _, err = qs.DownloadByQueryId(&buf, 1, Format("pdf"), nil); err == nil {
+		t.Fatal("unknown format is accepted")
+	}
+	if _, err = qs.DownloadByQueryId(&buf, 1, FormatXLSX, nil); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Error is not ErrNotFound, have: %v", err)
+	}
+
+	rr, err := qs.GetRowReaderById(1, 2, FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.Close()
+	record, err := rr.Read()
+	if err != nil || record[0] != "1" {
+		t.Fatalf("Record is bad, have: %v, %v", record, err)
+	}
+
+	rr2, err := qs.GetRowReaderByQueryId(1, FormatTSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr2.Close()
+	if cols := rr2.Columns(); len(cols) != 1 || cols[0] != "id" {
+		t.Fatalf("Columns is bad, have: %v", cols)
+	}
+}
+
+func TestDownloadTimeout(t *testing.T) {
+
+	// body takes longer than timeout of client.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "id\n")
+		w.(http.Flusher).Flush()
+		time.Sleep(200 * time.Millisecond)
+		io.WriteString(w, "1\n")
+	}))
+	defer ts.Close()
+	client, err := NewClient(ts.URL, "abcdefg", WithTimeout(50*time.Millisecond), WithRetryPolicy(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	qs := QueriesS{client}
+
+	var buf bytes.Buffer
+	if _, err = qs.DownloadByQueryId(&buf, 1, FormatCSV, nil); err != nil || buf.String() != "id\n1\n" {
+		t.Fatalf("Download is bad, have: %q, %v", buf.String(), err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	buf.Reset()
+	if _, err = qs.DownloadByQueryIdContext(ctx, &buf, 1, FormatCSV, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Error is not DeadlineExceeded, have: %v", err)
+	}
+}
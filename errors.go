@@ -0,0 +1,117 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody is max bytes of response body read for APIError.
+const maxErrorBody = 4096
+
+// Sentinel errors to check APIError with errors.Is.
+var (
+	ErrNotFound    = errors.New("redash: not found")
+	ErrForbidden   = errors.New("redash: forbidden")
+	ErrRateLimited = errors.New("redash: rate limited")
+)
+
+// APIError is returned when Redash responds with non-2xx status.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	// Message is Redash's "message" field, or raw body if it is not json.
+	Message   string
+	RequestId string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("redash: %s %s: %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestId != "" {
+		msg += " (request id: " + e.RequestId + ")"
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrNotFound) and so on work with APIError.
+// 401 Unauthorized is treated as ErrForbidden, as both mean bad apikey.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden || e.StatusCode == http.StatusUnauthorized
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// checkResponse return APIError and close body if resp is not 2xx.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	defer resp.Body.Close()
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestId:  resp.Header.Get("X-Request-Id"),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+	buf, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(buf, &body); err == nil && body.Message != "" {
+		apiErr.Message = body.Message
+	} else {
+		apiErr.Message = strings.TrimSpace(string(buf))
+	}
+	return apiErr
+}
+
+// DecodeError is returned when Redash response could not be decoded
+// into the wanted type.
+type DecodeError struct {
+	Type string
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("redash: failed to decode response into %s: %v", e.Type, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeBody decode json from r into v and close r if it is io.Closer.
+// If v is nil, r is only drained and closed.
+func decodeBody(r io.Reader, v interface{}) (err error) {
+	if c, ok := r.(io.Closer); ok {
+		defer c.Close()
+	}
+	if v == nil {
+		_, err = io.Copy(ioutil.Discard, r)
+		return err
+	}
+	if err = json.NewDecoder(r).Decode(v); err != nil {
+		return &DecodeError{Type: fmt.Sprintf("%T", v), Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package redash
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/queries/404", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Query not found."}`)
+	})
+	mux.HandleFunc("/api/queries/403", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Invalid Apikey", http.StatusForbidden)
+	})
+	mux.HandleFunc("/api/queries/429", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Too many", http.StatusTooManyRequests)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	qs := QueriesS{mockClientData{MockUrl: ts.URL}}
+
+	cases := []struct {
+		queryId  int
+		status   int
+		message  string
+		sentinel error
+	}{
+		{404, http.StatusNotFound, "Query not found.", ErrNotFound},
+		{403, http.StatusForbidden, "Invalid Apikey", ErrForbidden},
+		{429, http.StatusTooManyRequests, "Too many", ErrRateLimited},
+	}
+	for _, c := range cases {
+		r, err := qs.GetQueryId(c.queryId)
+		if r != nil {
+			t.Fatalf("Reader should be nil, have: %v", r)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Error is not APIError, have: %T %v", err, err)
+		}
+		if apiErr.StatusCode != c.status {
+			t.Fatalf("StatusCode is not match,\n want: %d,\n have: %d\n", c.status, apiErr.StatusCode)
+		}
+		if apiErr.Message != c.message {
+			t.Fatalf("Message is not match,\n want: %q,\n have: %q\n", c.message, apiErr.Message)
+		}
+		if want := fmt.Sprintf("/api/queries/%d", c.queryId); apiErr.Path != want || apiErr.Method != http.MethodGet {
+			t.Fatalf("Request is not match,\n want: GET %q,\n have: %s %q\n", want, apiErr.Method, apiErr.Path)
+		}
+		if !errors.Is(err, c.sentinel) {
+			t.Fatalf("errors.Is failed, want: %v, have: %v", c.sentinel, err)
+		}
+	}
+
+	_, err := qs.GetQueryIdTyped(404)
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrForbidden) {
+		t.Fatalf("Typed error is bad, have: %v", err)
+	}
+	if !strings.Contains(err.Error(), "req-1") {
+		t.Fatalf("Request id is not in error, have: %v", err)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+
+	err := decodeBody(strings.NewReader("Method Get not supported."), &[]ResponseQuery{})
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("Error is not DecodeError, have: %T %v", err, err)
+	}
+	if decodeErr.Type != "*[]redash.ResponseQuery" {
+		t.Fatalf("Type is not match, have: %q", decodeErr.Type)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Redash job status, see JobInner.Status.
+const (
+	JobPending   = 1
+	JobStarted   = 2
+	JobSuccess   = 3
+	JobFailure   = 4
+	JobCancelled = 5
+)
+
+// Backoff is exponential backoff setting.
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// Duration return wait duration before attempt(0 origin).
+func (b Backoff) Duration(attempt int) time.Duration {
+	d := float64(b.Initial)
+	for i := 0; i < attempt; i++ {
+		d *= b.Multiplier
+		if b.Max > 0 && d >= float64(b.Max) {
+			return b.Max
+		}
+	}
+	return time.Duration(d)
+}
+
+// ExecuteOptions is option for Execute.
+type ExecuteOptions struct {
+	// MaxAge is max age(seconds) of cached result, 0 means always run.
+	MaxAge int
+	// Backoff is interval setting to poll job.
+	Backoff Backoff
+	// Timeout is max duration of whole execution, 0 means no timeout.
+	Timeout time.Duration
+}
+
+// DefaultExecuteOptions is used when nil is given to Execute.
+var DefaultExecuteOptions = ExecuteOptions{
+	Backoff: Backoff{
+		Initial:    500 * time.Millisecond,
+		Max:        5 * time.Second,
+		Multiplier: 1.5,
+	},
+}
+
+// JobError is returned when Redash job is failed or cancelled.
+type JobError struct {
+	Job JobInner
+}
+
+func (e *JobError) Error() string {
+	if e.Job.Status == JobCancelled {
+		return fmt.Sprintf("redash: job %s cancelled", e.Job.Id)
+	}
+	return fmt.Sprintf("redash: job %s failed: %s", e.Job.Id, e.Job.Error)
+}
+
+// JobOrResult is response of POST query_results, which has job,
+// or query_result if cached result is available.
+type JobOrResult struct {
+	Job         *JobInner    `json:"job"`
+	QueryResult *QueryResult `json:"query_result"`
+}
+
+// Execute run query on data source and wait for the result.
+// It calls PostQueryResult, polls GetJob until job is done and
+// return result of GetQueryResults.
+// If ctx is done(or Timeout is passed) while waiting, the job is
+// cancelled on server by DeleteJog.
+func (qs QueriesS) Execute(ctx context.Context, query string, dataSourceId int, opts *ExecuteOptions) (qr *QueryResult, err error) {
+	return qs.execute(ctx, opts, func(ctx context.Context, maxAge int) (*JobOrResult, error) {
+		r, err := qs.PostQueryResultContext(ctx, query, maxAge, dataSourceId)
+		if err != nil {
+			return nil, err
+		}
+		jr := &JobOrResult{}
+		if err = decodeBody(r, jr); err != nil {
+			return nil, err
+		}
+		return jr, nil
+	})
+}
+
+// ExecuteQueryId run saved query with parameters and wait for the
+// result like Execute. Parameters are validated by PostResultsByQueryId.
+func (qs QueriesS) ExecuteQueryId(ctx context.Context, queryId int, params Parameters, opts *ExecuteOptions) (qr *QueryResult, err error) {
+	return qs.execute(ctx, opts, func(ctx context.Context, maxAge int) (*JobOrResult, error) {
+		return qs.PostResultsByQueryIdContext(ctx, queryId, params, maxAge)
+	})
+}
+
+// Refresh refresh saved query by PostRefresh and wait for the result
+// like Execute. opts.MaxAge is not used.
+func (qs QueriesS) Refresh(ctx context.Context, queryId int, opts *ExecuteOptions) (qr *QueryResult, err error) {
+	return qs.execute(ctx, opts, func(ctx context.Context, maxAge int) (*JobOrResult, error) {
+		r, err := qs.PostRefreshContext(ctx, queryId)
+		if err != nil {
+			return nil, err
+		}
+		jr := &JobOrResult{}
+		if err = decodeBody(r, jr); err != nil {
+			return nil, err
+		}
+		return jr, nil
+	})
+}
+
+// execute start job by post and wait for the result.
+func (qs QueriesS) execute(ctx context.Context, opts *ExecuteOptions, post func(ctx context.Context, maxAge int) (*JobOrResult, error)) (qr *QueryResult, err error) {
+	if opts == nil {
+		opts = &DefaultExecuteOptions
+	}
+	backoff := opts.Backoff
+	if backoff.Initial <= 0 {
+		backoff.Initial = DefaultExecuteOptions.Backoff.Initial
+	}
+	if backoff.Multiplier < 1 {
+		backoff.Multiplier = 1
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	jr, err := post(ctx, opts.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+	if jr.QueryResult != nil {
+		return jr.QueryResult, nil
+	}
+	if jr.Job == nil {
+		return nil, errors.New("redash: neither job nor query_result in response")
+	}
+	job, err := qs.waitJob(ctx, *jr.Job, backoff)
+	if err != nil {
+		return nil, err
+	}
+	result, err := qs.GetQueryResultsTypedContext(ctx, job.QueryResultId)
+	if err != nil {
+		return nil, err
+	}
+	return &result.QueryResult, nil
+}
+
+// waitJob polls job until it is finished.
+func (qs QueriesS) waitJob(ctx context.Context, job JobInner, backoff Backoff) (JobInner, error) {
+	for attempt := 0; ; attempt++ {
+		switch job.Status {
+		case JobSuccess:
+			return job, nil
+		case JobFailure, JobCancelled:
+			return job, &JobError{Job: job}
+		}
+		timer := time.NewTimer(backoff.Duration(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return job, qs.cancelJob(ctx, job)
+		case <-timer.C:
+		}
+		j, err := qs.GetJobTypedContext(ctx, job.Id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return job, qs.cancelJob(ctx, job)
+			}
+			return job, err
+		}
+		job = j.Job
+	}
+}
+
+// cancelJob cancel job on server after ctx is done and return ctx.Err().
+// As ctx is already done, job is cancelled with new context and error
+// of cancel is not reported.
+func (qs QueriesS) cancelJob(ctx context.Context, job JobInner) error {
+	_ = qs.DeleteJobTypedContext(context.Background(), job.Id)
+	return ctx.Err()
+}
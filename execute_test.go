@@ -0,0 +1,157 @@
+package redash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var fastExecuteOptions = &ExecuteOptions{
+	Backoff: Backoff{Initial: time.Millisecond, Max: 5 * time.Millisecond, Multiplier: 2},
+}
+
+// newExecuteServer make mock server which finishes job with finalStatus
+// after polled pending times. posted returns last body of POST
+// query_results.
+func newExecuteServer(pending int32, finalStatus int, deleted *int32) (ts *httptest.Server, posted func() string) {
+	jobId := "d856637d-9387-4874-a944-9c93ac45c688"
+	var polled int32
+	var body atomic.Value
+	body.Store("")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/query_results", func(w http.ResponseWriter, r *http.Request) {
+		buf, _ := ioutil.ReadAll(r.Body)
+		body.Store(string(buf))
+		fmt.Fprint(w, jobResp)
+	})
+	mux.HandleFunc("/api/queries/1/refresh", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, jobResp)
+	})
+	mux.HandleFunc("/api/jobs/"+jobId, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			atomic.AddInt32(deleted, 1)
+			fmt.Fprint(w, "null")
+			return
+		}
+		status, resultId := JobStarted, "null"
+		if atomic.AddInt32(&polled, 1) > pending {
+			status, resultId = finalStatus, "2"
+		}
+		fmt.Fprintf(w, `{"job": {"status": %d, "error": "boom", "id": "%s", "query_result_id": %s}}`, status, jobId, resultId)
+	})
+	mux.HandleFunc("/api/query_results/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, queryResultResp)
+	})
+	return httptest.NewServer(mux), func() string { return body.Load().(string) }
+}
+
+func TestBackoff(t *testing.T) {
+
+	b := Backoff{Initial: time.Second, Max: 3 * time.Second, Multiplier: 2}
+	wants := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}
+	for i, want := range wants {
+		if have := b.Duration(i); have != want {
+			t.Fatalf("Duration(%d) is bad,\n want: %v,\n have: %v\n", i, want, have)
+		}
+	}
+}
+
+func TestExecute(t *testing.T) {
+
+	var deleted int32
+	ts, posted := newExecuteServer(2, JobSuccess, &deleted)
+	defer ts.Close()
+	qs := QueriesS{mockClientData{MockUrl: ts.URL}}
+
+	qr, err := qs.Execute(context.Background(), `select * from "hello";`, 1, fastExecuteOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"query":"select * from \"hello\";","data_source_id":1,"max_age":0}`; posted() != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, posted())
+	}
+	if qr.Id != 2 {
+		t.Fatalf("Query result id is not match,\n want: %d,\n have: %d\n", 2, qr.Id)
+	}
+	if deleted != 0 {
+		t.Fatalf("Job should not be deleted, have: %d", deleted)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+
+	var deleted int32
+	ts, _ := newExecuteServer(1, JobSuccess, &deleted)
+	defer ts.Close()
+	qs := QueriesS{mockClientData{MockUrl: ts.URL}}
+
+	qr, err := qs.Refresh(context.Background(), 1, fastExecuteOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qr.Id != 2 {
+		t.Fatalf("Query result id is not match,\n want: %d,\n have: %d\n", 2, qr.Id)
+	}
+}
+
+func TestExecuteCached(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/query_results" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, queryResultResp)
+	}))
+	defer ts.Close()
+	qs := QueriesS{mockClientData{MockUrl: ts.URL}}
+
+	qr, err := qs.Execute(context.Background(), "select * from hello;", 1, &ExecuteOptions{MaxAge: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(qr.Data.Rows) != 2 {
+		t.Fatalf("Rows num is bad,\n want: %d,\n have: %d\n", 2, len(qr.Data.Rows))
+	}
+}
+
+func TestExecuteFailure(t *testing.T) {
+
+	var deleted int32
+	ts, _ := newExecuteServer(1, JobFailure, &deleted)
+	defer ts.Close()
+	qs := QueriesS{mockClientData{MockUrl: ts.URL}}
+
+	_, err := qs.Execute(context.Background(), "select * from hello;", 1, fastExecuteOptions)
+	var jobErr *JobError
+	if !errors.As(err, &jobErr) {
+		t.Fatalf("Error is not JobError, have: %T %v", err, err)
+	}
+	if jobErr.Job.Error != "boom" {
+		t.Fatalf("Job error is not match,\n want: %q,\n have: %q\n", "boom", jobErr.Job.Error)
+	}
+}
+
+func TestExecuteTimeout(t *testing.T) {
+
+	var deleted int32
+	ts, _ := newExecuteServer(1<<30, JobSuccess, &deleted)
+	defer ts.Close()
+	qs := QueriesS{mockClientData{MockUrl: ts.URL}}
+
+	opts := *fastExecuteOptions
+	opts.Timeout = 20 * time.Millisecond
+	_, err := qs.Execute(context.Background(), "select * from hello;", 1, &opts)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Error is not DeadlineExceeded, have: %v", err)
+	}
+	if atomic.LoadInt32(&deleted) != 1 {
+		t.Fatalf("Job should be deleted once, have: %d", deleted)
+	}
+}
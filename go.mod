@@ -0,0 +1,3 @@
+module github.com/ynishi/redash
+
+go 1.23
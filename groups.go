@@ -0,0 +1,215 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Default Groups
+var Groups = &GroupsS{DefaultClient}
+
+// interface of make Groups endpoint.
+type Groupser interface {
+	Groups(string) string
+}
+
+// Default struct for groups.
+type GroupsS struct {
+	Client Interface
+}
+
+// Default implement of Groups.
+func (gs GroupsS) Groups(s string) (rs string) {
+	return "/api/groups/" + s
+}
+
+// Wrap Redash group.
+type Group struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+	// Type is "builtin" or "regular".
+	Type        string   `json:"type"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// Wrap Redash api GET groups.
+func (gs GroupsS) GetGroups() (groups []Group, err error) {
+	return gs.GetGroupsContext(context.Background())
+}
+
+// GetGroupsContext is GetGroups with context.
+func (gs GroupsS) GetGroupsContext(ctx context.Context) (groups []Group, err error) {
+	if err = doJSON(ctx, gs.Client, http.MethodGet, gs.Groups(""), nil, nil, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// Wrap Redash api GET groups/${group id}.
+func (gs GroupsS) GetGroup(groupId int) (g *Group, err error) {
+	return gs.GetGroupContext(context.Background(), groupId)
+}
+
+// GetGroupContext is GetGroup with context.
+func (gs GroupsS) GetGroupContext(ctx context.Context, groupId int) (g *Group, err error) {
+	g = &Group{}
+	if err = doJSON(ctx, gs.Client, http.MethodGet, gs.Groups(strconv.Itoa(groupId)), nil, nil, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Wrap Redash api POST groups.
+func (gs GroupsS) PostGroup(name string) (g *Group, err error) {
+	return gs.PostGroupContext(context.Background(), name)
+}
+
+// PostGroupContext is PostGroup with context.
+func (gs GroupsS) PostGroupContext(ctx context.Context, name string) (g *Group, err error) {
+	body := struct {
+		Name string `json:"name"`
+	}{name}
+	g = &Group{}
+	if err = doJSON(ctx, gs.Client, http.MethodPost, gs.Groups(""), nil, body, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Wrap Redash api POST groups/${group id}, which renames group.
+func (gs GroupsS) PostGroupId(groupId int, name string) (g *Group, err error) {
+	return gs.PostGroupIdContext(context.Background(), groupId, name)
+}
+
+// PostGroupIdContext is PostGroupId with context.
+func (gs GroupsS) PostGroupIdContext(ctx context.Context, groupId int, name string) (g *Group, err error) {
+	body := struct {
+		Name string `json:"name"`
+	}{name}
+	g = &Group{}
+	if err = doJSON(ctx, gs.Client, http.MethodPost, gs.Groups(strconv.Itoa(groupId)), nil, body, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Wrap Redash api DELETE groups/${group id}.
+func (gs GroupsS) DeleteGroup(groupId int) (err error) {
+	return gs.DeleteGroupContext(context.Background(), groupId)
+}
+
+// DeleteGroupContext is DeleteGroup with context.
+func (gs GroupsS) DeleteGroupContext(ctx context.Context, groupId int) (err error) {
+	return doJSON(ctx, gs.Client, http.MethodDelete, gs.Groups(strconv.Itoa(groupId)), nil, nil, nil)
+}
+
+// Wrap Redash api GET members.
+func (gs GroupsS) GetMembers(groupId int) (users []User, err error) {
+	return gs.GetMembersContext(context.Background(), groupId)
+}
+
+// GetMembersContext is GetMembers with context.
+func (gs GroupsS) GetMembersContext(ctx context.Context, groupId int) (users []User, err error) {
+	if err = doJSON(ctx, gs.Client, http.MethodGet, gs.Groups(fmt.Sprintf("%d/members", groupId)), nil, nil, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Wrap Redash api POST members, which adds user to group.
+func (gs GroupsS) PostMember(groupId, userId int) (u *User, err error) {
+	return gs.PostMemberContext(context.Background(), groupId, userId)
+}
+
+// PostMemberContext is PostMember with context.
+func (gs GroupsS) PostMemberContext(ctx context.Context, groupId, userId int) (u *User, err error) {
+	body := struct {
+		UserId int `json:"user_id"`
+	}{userId}
+	u = &User{}
+	if err = doJSON(ctx, gs.Client, http.MethodPost, gs.Groups(fmt.Sprintf("%d/members", groupId)), nil, body, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Wrap Redash api DELETE members/${user id}, which removes user from group.
+func (gs GroupsS) DeleteMember(groupId, userId int) (err error) {
+	return gs.DeleteMemberContext(context.Background(), groupId, userId)
+}
+
+// DeleteMemberContext is DeleteMember with context.
+func (gs GroupsS) DeleteMemberContext(ctx context.Context, groupId, userId int) (err error) {
+	return doJSON(ctx, gs.Client, http.MethodDelete, gs.Groups(fmt.Sprintf("%d/members/%d", groupId, userId)), nil, nil, nil)
+}
+
+// Wrap Redash api GET data_sources of group, ViewOnly of each data
+// source is access of the group.
+func (gs GroupsS) GetDataSources(groupId int) (dataSources []DataSource, err error) {
+	return gs.GetDataSourcesContext(context.Background(), groupId)
+}
+
+// GetDataSourcesContext is GetDataSources with context.
+func (gs GroupsS) GetDataSourcesContext(ctx context.Context, groupId int) (dataSources []DataSource, err error) {
+	if err = doJSON(ctx, gs.Client, http.MethodGet, gs.Groups(fmt.Sprintf("%d/data_sources", groupId)), nil, nil, &dataSources); err != nil {
+		return nil, err
+	}
+	return dataSources, nil
+}
+
+// Wrap Redash api POST data_sources of group, which grants group
+// access to data source. If viewOnly, group can only view results.
+func (gs GroupsS) PostDataSource(groupId, dataSourceId int, viewOnly bool) (ds *DataSource, err error) {
+	return gs.PostDataSourceContext(context.Background(), groupId, dataSourceId, viewOnly)
+}
+
+// PostDataSourceContext is PostDataSource with context.
+func (gs GroupsS) PostDataSourceContext(ctx context.Context, groupId, dataSourceId int, viewOnly bool) (ds *DataSource, err error) {
+	body := struct {
+		DataSourceId int `json:"data_source_id"`
+	}{dataSourceId}
+	ds = &DataSource{}
+	if err = doJSON(ctx, gs.Client, http.MethodPost, gs.Groups(fmt.Sprintf("%d/data_sources", groupId)), nil, body, ds); err != nil {
+		return nil, err
+	}
+	if !viewOnly {
+		return ds, nil
+	}
+	return gs.PostDataSourceIdContext(ctx, groupId, dataSourceId, viewOnly)
+}
+
+// Wrap Redash api POST data_sources/${data source id} of group, which
+// changes view only access of group.
+func (gs GroupsS) PostDataSourceId(groupId, dataSourceId int, viewOnly bool) (ds *DataSource, err error) {
+	return gs.PostDataSourceIdContext(context.Background(), groupId, dataSourceId, viewOnly)
+}
+
+// PostDataSourceIdContext is PostDataSourceId with context.
+func (gs GroupsS) PostDataSourceIdContext(ctx context.Context, groupId, dataSourceId int, viewOnly bool) (ds *DataSource, err error) {
+	body := struct {
+		ViewOnly bool `json:"view_only"`
+	}{viewOnly}
+	ds = &DataSource{}
+	if err = doJSON(ctx, gs.Client, http.MethodPost, gs.Groups(fmt.Sprintf("%d/data_sources/%d", groupId, dataSourceId)), nil, body, ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// Wrap Redash api DELETE data_sources/${data source id} of group,
+// which revokes access of group to data source.
+func (gs GroupsS) DeleteDataSource(groupId, dataSourceId int) (err error) {
+	return gs.DeleteDataSourceContext(context.Background(), groupId, dataSourceId)
+}
+
+// DeleteDataSourceContext is DeleteDataSource with context.
+func (gs GroupsS) DeleteDataSourceContext(ctx context.Context, groupId, dataSourceId int) (err error) {
+	return doJSON(ctx, gs.Client, http.MethodDelete, gs.Groups(fmt.Sprintf("%d/data_sources/%d", groupId, dataSourceId)), nil, nil, nil)
+}
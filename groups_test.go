@@ -0,0 +1,96 @@
+package redash
+
+import (
+	"testing"
+)
+
+const groupResp = `{
+  "id": 3,
+  "name": "analysts",
+  "type": "regular",
+  "permissions": ["view_query", "execute_query"],
+  "created_at": "2017-07-16T10:15:31.897134+00:00"
+}`
+
+func TestGroups(t *testing.T) {
+
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"GET /api/groups":                     {body: "[" + groupResp + "]"},
+		"POST /api/groups":                    {body: groupResp},
+		"GET /api/groups/3":                   {body: groupResp},
+		"POST /api/groups/3":                  {body: groupResp},
+		"DELETE /api/groups/3":                {body: `null`},
+		"GET /api/groups/3/members":           {body: "[" + userResp + "]"},
+		"POST /api/groups/3/members":          {body: userResp},
+		"DELETE /api/groups/3/members/2":      {body: `null`},
+		"GET /api/groups/3/data_sources":      {body: `[{"id": 1, "name": "pg", "view_only": true}]`},
+		"POST /api/groups/3/data_sources":     {body: `{"id": 1, "name": "pg", "view_only": false}`},
+		"POST /api/groups/3/data_sources/1":   {body: `{"id": 1, "name": "pg", "view_only": true}`},
+		"DELETE /api/groups/3/data_sources/1": {body: `null`},
+	})
+	gs := GroupsS{client}
+
+	groups, err := gs.GetGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0].Type != "regular" || len(groups[0].Permissions) != 2 {
+		t.Fatalf("Groups is bad, have: %+v", groups)
+	}
+	if _, err = gs.GetGroup(3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = gs.PostGroup("analysts"); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"analysts"}`; bodies["POST /api/groups"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/groups"])
+	}
+	if _, err = gs.PostGroupId(3, "analysts2"); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := gs.GetMembers(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].Id != 2 {
+		t.Fatalf("Members is bad, have: %+v", members)
+	}
+	if _, err = gs.PostMember(3, 2); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"user_id":2}`; bodies["POST /api/groups/3/members"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/groups/3/members"])
+	}
+	if err = gs.DeleteMember(3, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	dataSources, err := gs.GetDataSources(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dataSources) != 1 || !dataSources[0].ViewOnly {
+		t.Fatalf("DataSources is bad, have: %+v", dataSources)
+	}
+	ds, err := gs.PostDataSource(3, 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ds.ViewOnly {
+		t.Fatalf("DataSource should be view only, have: %+v", ds)
+	}
+	if want := `{"data_source_id":1}`; bodies["POST /api/groups/3/data_sources"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/groups/3/data_sources"])
+	}
+	if want := `{"view_only":true}`; bodies["POST /api/groups/3/data_sources/1"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/groups/3/data_sources/1"])
+	}
+	if err = gs.DeleteDataSource(3, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = gs.DeleteGroup(3); err != nil {
+		t.Fatal(err)
+	}
+}
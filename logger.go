@@ -0,0 +1,100 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+)
+
+// LogLevel is level of log, values are same as slog.Level.
+type LogLevel int
+
+// Log levels.
+const (
+	LevelDebug LogLevel = -4
+	LevelInfo  LogLevel = 0
+	LevelWarn  LogLevel = 4
+	LevelError LogLevel = 8
+)
+
+func (l LogLevel) String() string {
+	return slog.Level(l).String()
+}
+
+// Logger is levelled logger used by client.
+// keysAndValues are pairs of key and value like slog, e.g.
+// "method", "GET", "status", 200.
+type Logger interface {
+	Log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{})
+}
+
+// Loggerer is optional interface for client of Interface.
+// If client implements it, DoInter logs requests to the logger.
+type Loggerer interface {
+	ClientLogger() Logger
+}
+
+// NopLogger discards all logs, this is default logger.
+var NopLogger Logger = nopLogger{}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(context.Context, LogLevel, string, ...interface{}) {}
+
+// slogLogger is adapter of slog.Logger.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger return Logger writing to slog.Logger.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	return slogLogger{logger}
+}
+
+func (sl slogLogger) Log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+	sl.logger.Log(ctx, slog.Level(level), msg, keysAndValues...)
+}
+
+// stdLogger is adapter of log.Logger.
+type stdLogger struct {
+	logger *log.Logger
+	level  LogLevel
+}
+
+// NewStdLogger return Logger writing to log.Logger logs of which
+// level is level or higher, like "[INFO] msg key=value".
+func NewStdLogger(logger *log.Logger, level LogLevel) Logger {
+	return stdLogger{logger, level}
+}
+
+func (sl stdLogger) Log(_ context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+	if level < sl.level {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", level, msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(&b, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+		} else {
+			fmt.Fprintf(&b, " %v", keysAndValues[i])
+		}
+	}
+	sl.logger.Print(b.String())
+}
+
+// clientLogger return logger of client, or NopLogger.
+func clientLogger(client Interface) Logger {
+	if l, ok := client.(Loggerer); ok {
+		if logger := l.ClientLogger(); logger != nil {
+			return logger
+		}
+	}
+	return NopLogger
+}
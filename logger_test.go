@@ -0,0 +1,76 @@
+package redash
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("null"))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	client, err := NewClient(ts.URL, "abcdefg", WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = (QueriesS{client}).DeleteQueryTyped(1); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"level=DEBUG msg=\"redash: apikey\" apikey=abcd****",
+		"level=INFO msg=\"redash: request\" method=DELETE path=/api/queries/1 status=200 latency=",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("Log is not match,\n want: %q,\n have: %q\n", want, out)
+		}
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+
+	var buf bytes.Buffer
+	logger := NewStdLogger(log.New(&buf, "", 0), LevelInfo)
+	logger.Log(context.Background(), LevelDebug, "debug")
+	logger.Log(context.Background(), LevelWarn, "warn", "status", 503, "odd")
+	if want := "[WARN] warn status=503 odd\n"; buf.String() != want {
+		t.Fatalf("Log is not match,\n want: %q,\n have: %q\n", want, buf.String())
+	}
+}
+
+func TestClientDataLogger(t *testing.T) {
+
+	// ClientData with log.Logger works as before.
+	var buf bytes.Buffer
+	cd := ClientData{Logger: log.New(&buf, "", 0)}
+	cd.Printf("hello %d", 1)
+	cd.ClientLogger().Log(context.Background(), LevelDebug, "debug", "k", "v")
+	if want := "hello 1\n[DEBUG] debug k=v\n"; buf.String() != want {
+		t.Fatalf("Log is not match,\n want: %q,\n have: %q\n", want, buf.String())
+	}
+	cd.LevelLogger = NopLogger
+	if cd.ClientLogger() != NopLogger {
+		t.Fatal("LevelLogger should be used first")
+	}
+}
+
+func TestNopLogger(t *testing.T) {
+
+	if clientLogger(mockClient) != NopLogger {
+		t.Fatal("Logger of client without Loggerer should be NopLogger")
+	}
+	if (ClientData{}).ClientLogger() != NopLogger {
+		t.Fatal("Logger of ClientData should be NopLogger by default")
+	}
+}
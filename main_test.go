@@ -2,10 +2,12 @@ package redash
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"sync"
 	"testing"
 )
 
@@ -58,3 +60,39 @@ func TestMain(m *testing.M) {
 	v := m.Run()
 	os.Exit(v)
 }
+
+// mockRoute is response of mock server for "METHOD /path".
+type mockRoute struct {
+	status int
+	body   string
+}
+
+// newMockServer make mock server which responds routes keyed by
+// "METHOD /path", and return client for it. Request bodies are
+// recorded into bodies keyed same as routes.
+func newMockServer(t *testing.T, routes map[string]mockRoute) (client mockClientData, bodies map[string]string) {
+	bodies = make(map[string]string)
+	var mu sync.Mutex
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		buf, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		bodies[key] = string(buf)
+		// raw query is recorded with key suffixed "?".
+		bodies[key+"?"] = r.URL.RawQuery
+		mu.Unlock()
+		route, ok := routes[key]
+		if !ok {
+			http.Error(w, `{"message": "not found"}`, http.StatusNotFound)
+			return
+		}
+		if route.status == 0 {
+			route.status = http.StatusOK
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(route.status)
+		fmt.Fprint(w, route.body)
+	}))
+	t.Cleanup(ts.Close)
+	return mockClientData{MockUrl: ts.URL}, bodies
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize is page size of iterators when pageSize is not positive.
+const DefaultPageSize = 25
+
+// fetchPage get items of page and total count of items.
+type fetchPage[T any] func(ctx context.Context, pageSize, page int) (items []T, count int, err error)
+
+// pages walk every page lazily by fetch. Next page is fetched only when
+// all items of current page are yielded, so breaking the loop stops
+// fetching. Error is yielded once with zero value, then stops.
+func pages[T any](ctx context.Context, pageSize int, fetch fetchPage[T]) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return func(yield func(T, error) bool) {
+		seen := 0
+		for page := 1; ; page++ {
+			items, count, err := fetch(ctx, pageSize, page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			seen += len(items)
+			if len(items) == 0 || seen >= count {
+				return
+			}
+		}
+	}
+}
+
+// Collect gather all items of seq, stops at first error.
+func Collect[T any](seq iter.Seq2[T, error]) (items []T, err error) {
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// IterQuery iterate all queries of GetQuery page by page.
+func (qs QueriesS) IterQuery(pageSize int) iter.Seq2[ResponseQuery, error] {
+	return qs.IterQueryContext(context.Background(), pageSize)
+}
+
+// IterQueryContext is IterQuery with context.
+func (qs QueriesS) IterQueryContext(ctx context.Context, pageSize int) iter.Seq2[ResponseQuery, error] {
+	return pages(ctx, pageSize, func(ctx context.Context, pageSize, page int) ([]ResponseQuery, int, error) {
+		prq, err := qs.GetQueryTypedContext(ctx, pageSize, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return prq.Results, prq.Count, nil
+	})
+}
+
+// IterQueryByTags iterate all queries having all of tags, of
+// GetQueryByTags page by page.
+func (qs QueriesS) IterQueryByTags(pageSize int, tags ...string) iter.Seq2[ResponseQuery, error] {
+	return qs.IterQueryByTagsContext(context.Background(), pageSize, tags...)
+}
+
+// IterQueryByTagsContext is IterQueryByTags with context.
+func (qs QueriesS) IterQueryByTagsContext(ctx context.Context, pageSize int, tags ...string) iter.Seq2[ResponseQuery, error] {
+	return pages(ctx, pageSize, func(ctx context.Context, pageSize, page int) ([]ResponseQuery, int, error) {
+		prq, err := qs.GetQueryByTagsContext(ctx, pageSize, page, tags...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return prq.Results, prq.Count, nil
+	})
+}
+
+// IterMy iterate all my queries of GetMy page by page.
+func (qs QueriesS) IterMy(pageSize int) iter.Seq2[ResponseQuery, error] {
+	return qs.IterMyContext(context.Background(), pageSize)
+}
+
+// IterMyContext is IterMy with context.
+func (qs QueriesS) IterMyContext(ctx context.Context, pageSize int) iter.Seq2[ResponseQuery, error] {
+	return pages(ctx, pageSize, func(ctx context.Context, pageSize, page int) ([]ResponseQuery, int, error) {
+		prq, err := qs.GetMyTypedContext(ctx, pageSize, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return prq.Results, prq.Count, nil
+	})
+}
+
+// IterDashboards iterate all dashboards of GetDashboards page by page.
+func (ds DashboardsS) IterDashboards(pageSize int) iter.Seq2[Dashboard, error] {
+	return ds.IterDashboardsContext(context.Background(), pageSize)
+}
+
+// IterDashboardsContext is IterDashboards with context.
+func (ds DashboardsS) IterDashboardsContext(ctx context.Context, pageSize int) iter.Seq2[Dashboard, error] {
+	return pages(ctx, pageSize, func(ctx context.Context, pageSize, page int) ([]Dashboard, int, error) {
+		prd, err := ds.GetDashboardsContext(ctx, pageSize, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return prd.Results, prd.Count, nil
+	})
+}
+
+// IterUsers iterate all users of GetUsers page by page, q is same as
+// GetUsers.
+func (us UsersS) IterUsers(pageSize int, q string) iter.Seq2[User, error] {
+	return us.IterUsersContext(context.Background(), pageSize, q)
+}
+
+// IterUsersContext is IterUsers with context.
+func (us UsersS) IterUsersContext(ctx context.Context, pageSize int, q string) iter.Seq2[User, error] {
+	return pages(ctx, pageSize, func(ctx context.Context, pageSize, page int) ([]User, int, error) {
+		pru, err := us.GetUsersContext(ctx, pageSize, page, q)
+		if err != nil {
+			return nil, 0, err
+		}
+		return pru.Results, pru.Count, nil
+	})
+}
+
+// IterAlerts iterate all alerts. Alerts api is not paged, so all alerts
+// are fetched at once and pageSize is not needed.
+func (as AlertsS) IterAlerts() iter.Seq2[Alert, error] {
+	return as.IterAlertsContext(context.Background())
+}
+
+// IterAlertsContext is IterAlerts with context.
+func (as AlertsS) IterAlertsContext(ctx context.Context) iter.Seq2[Alert, error] {
+	return pages(ctx, 0, func(ctx context.Context, pageSize, page int) ([]Alert, int, error) {
+		alerts, err := as.GetAlertsContext(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		return alerts, len(alerts), nil
+	})
+}
@@ -0,0 +1,139 @@
+package redash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// newPagingServer serve total items of path by page, and count requests.
+func newPagingServer(t *testing.T, path string, total int) (client mockClientData, requests func() int) {
+	var mu sync.Mutex
+	n := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.Error(w, `{"message": "not found"}`, http.StatusNotFound)
+			return
+		}
+		mu.Lock()
+		n++
+		mu.Unlock()
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		results := ""
+		for id := (page-1)*pageSize + 1; id <= page*pageSize && id <= total; id++ {
+			if results != "" {
+				results += ","
+			}
+			results += fmt.Sprintf(`{"id": %d}`, id)
+		}
+		fmt.Fprintf(w, `{"count": %d, "page": %d, "page_size": %d, "results": [%s]}`, total, page, pageSize, results)
+	}))
+	t.Cleanup(ts.Close)
+	return mockClientData{MockUrl: ts.URL}, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return n
+	}
+}
+
+func TestIterQuery(t *testing.T) {
+	client, requests := newPagingServer(t, "/api/queries", 7)
+	qs := QueriesS{client}
+
+	rqs, err := Collect(qs.IterQuery(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rqs) != 7 || rqs[0].Id != 1 || rqs[6].Id != 7 {
+		t.Fatalf("Queries is bad, have: %+v", rqs)
+	}
+	if have := requests(); have != 3 {
+		t.Fatalf("Requests is not match, want: 3, have: %d", have)
+	}
+
+	// break at first item of second page.
+	for rq, err := range qs.IterQuery(3) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rq.Id == 4 {
+			break
+		}
+	}
+	if have := requests(); have != 5 {
+		t.Fatalf("Requests is not match, want: 5, have: %d", have)
+	}
+}
+
+func TestIterQueryByTags(t *testing.T) {
+	client, requests := newPagingServer(t, "/api/queries", 5)
+	qs := QueriesS{client}
+
+	rqs, err := Collect(qs.IterQueryByTags(2, "a", "b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rqs) != 5 || rqs[0].Id != 1 || rqs[4].Id != 5 {
+		t.Fatalf("Queries is bad, have: %+v", rqs)
+	}
+	if have := requests(); have != 3 {
+		t.Fatalf("Requests is not match, want: 3, have: %d", have)
+	}
+}
+
+func TestIterDashboardsAndUsers(t *testing.T) {
+	client, _ := newPagingServer(t, "/api/dashboards", 30)
+	ds, err := Collect(DashboardsS{client}.IterDashboards(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ds) != 30 {
+		t.Fatalf("Dashboards is bad, have: %d", len(ds))
+	}
+
+	client, requests := newPagingServer(t, "/api/users", 0)
+	us, err := Collect(UsersS{client}.IterUsers(10, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(us) != 0 || requests() != 1 {
+		t.Fatalf("Users is bad, have: %+v", us)
+	}
+}
+
+func TestIterAlerts(t *testing.T) {
+	client, _ := newMockServer(t, map[string]mockRoute{
+		"GET /api/alerts": {body: "[" + alertResp + "," + alertResp + "]"},
+	})
+	alerts, err := Collect(AlertsS{client}.IterAlerts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("Alerts is bad, have: %+v", alerts)
+	}
+}
+
+func TestIterError(t *testing.T) {
+	client, _ := newMockServer(t, map[string]mockRoute{})
+	rqs, err := Collect(QueriesS{client}.IterMy(10))
+	if !errors.Is(err, ErrNotFound) || len(rqs) != 0 {
+		t.Fatalf("Error is not ErrNotFound, have: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client, requests := newPagingServer(t, "/api/queries", 5)
+	if _, err = Collect(QueriesS{client}.IterQueryContext(ctx, 2)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Error is not Canceled, have: %v", err)
+	}
+	if have := requests(); have != 0 {
+		t.Fatalf("Requests is not match, want: 0, have: %d", have)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Redash query parameter types, see QueryParameter.Type.
+const (
+	ParamText                     = "text"
+	ParamNumber                   = "number"
+	ParamEnum                     = "enum"
+	ParamQuery                    = "query"
+	ParamDate                     = "date"
+	ParamDatetimeLocal            = "datetime-local"
+	ParamDatetimeWithSeconds      = "datetime-with-seconds"
+	ParamDateRange                = "date-range"
+	ParamDatetimeRange            = "datetime-range"
+	ParamDatetimeRangeWithSeconds = "datetime-range-with-seconds"
+)
+
+// Errors of parameter validation, wrapped by ParameterError.
+var (
+	ErrUnknownParameter = errors.New("redash: unknown parameter")
+	ErrMissingParameter = errors.New("redash: missing parameter")
+	ErrParameterType    = errors.New("redash: parameter type mismatch")
+	ErrParameterValue   = errors.New("redash: invalid parameter value")
+)
+
+// ParameterError is returned when parameter is not valid for the query.
+type ParameterError struct {
+	Name string
+	Err  error
+}
+
+func (e *ParameterError) Error() string {
+	return fmt.Sprintf("redash: parameter %q: %v", e.Name, e.Err)
+}
+
+func (e *ParameterError) Unwrap() error {
+	return e.Err
+}
+
+// Wrap Redash query options.
+type QueryOptions struct {
+	Parameters []QueryParameter `json:"parameters"`
+}
+
+// Wrap Redash query parameter declared in query options.
+type QueryParameter struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	Type  string `json:"type"`
+	// Value is default value, nil if not set.
+	Value  interface{} `json:"value"`
+	Global bool        `json:"global"`
+	// EnumOptions is new line separated options of enum.
+	EnumOptions string `json:"enumOptions,omitempty"`
+	// QueryId is id of query for dropdown options of query type.
+	QueryId int `json:"queryId,omitempty"`
+	// MultiValuesOptions is set if multiple values are allowed.
+	MultiValuesOptions *MultiValuesOptions `json:"multiValuesOptions,omitempty"`
+}
+
+// Wrap Redash multiple values options of enum and query parameters.
+type MultiValuesOptions struct {
+	Prefix    string `json:"prefix"`
+	Suffix    string `json:"suffix"`
+	Separator string `json:"separator"`
+}
+
+// ParameterValue is typed value of a query parameter, one of TextParam,
+// NumberParam, EnumParam, QueryParam, DateParam and DateRangeParam.
+type ParameterValue interface {
+	// encode validate value with declared parameter and return json
+	// value to send.
+	encode(p QueryParameter) (interface{}, error)
+}
+
+// Parameters is parameter values by name.
+type Parameters map[string]ParameterValue
+
+// TextParam is value of text parameter.
+type TextParam string
+
+// NumberParam is value of number parameter.
+type NumberParam float64
+
+// EnumParam is values of enum(dropdown list) parameter. It must be one
+// value unless multiple values are allowed.
+type EnumParam []string
+
+// QueryParam is values of query based dropdown parameter. It must be
+// one value unless multiple values are allowed.
+type QueryParam []string
+
+// DateParam is value of date, datetime-local and datetime-with-seconds
+// parameters.
+type DateParam time.Time
+
+// DateRangeParam is value of date-range, datetime-range and
+// datetime-range-with-seconds parameters.
+type DateRangeParam struct {
+	Start time.Time
+	End   time.Time
+}
+
+// dateLayouts is layout of value by parameter type.
+var dateLayouts = map[string]string{
+	ParamDate:                     "2006-01-02",
+	ParamDatetimeLocal:            "2006-01-02 15:04",
+	ParamDatetimeWithSeconds:      "2006-01-02 15:04:05",
+	ParamDateRange:                "2006-01-02",
+	ParamDatetimeRange:            "2006-01-02 15:04",
+	ParamDatetimeRangeWithSeconds: "2006-01-02 15:04:05",
+}
+
+func (v TextParam) encode(p QueryParameter) (interface{}, error) {
+	if p.Type != ParamText {
+		return nil, ErrParameterType
+	}
+	return string(v), nil
+}
+
+func (v NumberParam) encode(p QueryParameter) (interface{}, error) {
+	if p.Type != ParamNumber {
+		return nil, ErrParameterType
+	}
+	return float64(v), nil
+}
+
+func (v EnumParam) encode(p QueryParameter) (interface{}, error) {
+	if p.Type != ParamEnum {
+		return nil, ErrParameterType
+	}
+	options := strings.Split(strings.ReplaceAll(p.EnumOptions, "\r\n", "\n"), "\n")
+	for _, value := range v {
+		found := false
+		for _, option := range options {
+			if value == option {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %q is not in options", ErrParameterValue, value)
+		}
+	}
+	return encodeValues(p, v)
+}
+
+func (v QueryParam) encode(p QueryParameter) (interface{}, error) {
+	if p.Type != ParamQuery {
+		return nil, ErrParameterType
+	}
+	return encodeValues(p, v)
+}
+
+// encodeValues return one value as string, or values as array if
+// multiple values are allowed.
+func encodeValues(p QueryParameter, values []string) (interface{}, error) {
+	if p.MultiValuesOptions != nil {
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%w: no value", ErrParameterValue)
+		}
+		return values, nil
+	}
+	if len(values) != 1 {
+		return nil, fmt.Errorf("%w: multiple values are not allowed", ErrParameterValue)
+	}
+	return values[0], nil
+}
+
+func (v DateParam) encode(p QueryParameter) (interface{}, error) {
+	switch p.Type {
+	case ParamDate, ParamDatetimeLocal, ParamDatetimeWithSeconds:
+		return time.Time(v).Format(dateLayouts[p.Type]), nil
+	}
+	return nil, ErrParameterType
+}
+
+func (v DateRangeParam) encode(p QueryParameter) (interface{}, error) {
+	switch p.Type {
+	case ParamDateRange, ParamDatetimeRange, ParamDatetimeRangeWithSeconds:
+	default:
+		return nil, ErrParameterType
+	}
+	if v.End.Before(v.Start) {
+		return nil, fmt.Errorf("%w: end is before start", ErrParameterValue)
+	}
+	layout := dateLayouts[p.Type]
+	return map[string]string{"start": v.Start.Format(layout), "end": v.End.Format(layout)}, nil
+}
+
+// Encode validate ps with parameters declared in query options and
+// return json values by name. Parameters not in ps are filled with
+// default value, as Redash api does not fill them, and it is error if
+// there is no default value.
+func (ps Parameters) Encode(declared []QueryParameter) (values map[string]interface{}, err error) {
+	byName := make(map[string]QueryParameter, len(declared))
+	for _, p := range declared {
+		byName[p.Name] = p
+	}
+	values = make(map[string]interface{}, len(ps))
+	for name, v := range ps {
+		p, ok := byName[name]
+		if !ok {
+			return nil, &ParameterError{Name: name, Err: ErrUnknownParameter}
+		}
+		if v == nil {
+			return nil, &ParameterError{Name: name, Err: fmt.Errorf("%w: nil", ErrParameterValue)}
+		}
+		if values[name], err = v.encode(p); err != nil {
+			return nil, &ParameterError{Name: name, Err: err}
+		}
+	}
+	for _, p := range declared {
+		if _, ok := ps[p.Name]; ok {
+			continue
+		}
+		if p.Value == nil {
+			return nil, &ParameterError{Name: p.Name, Err: ErrMissingParameter}
+		}
+		values[p.Name] = p.Value
+	}
+	return values, nil
+}
+
+// Wrap Redash api POST queries/${query id}/results. Parameters are
+// validated with options of the query got by GetQueryIdTyped, and
+// response has job, or query_result if cached result of maxAge is
+// available.
+func (qs QueriesS) PostResultsByQueryId(queryId int, params Parameters, maxAge int) (jr *JobOrResult, err error) {
+	return qs.PostResultsByQueryIdContext(context.Background(), queryId, params, maxAge)
+}
+
+// PostResultsByQueryIdContext is PostResultsByQueryId with context.
+func (qs QueriesS) PostResultsByQueryIdContext(ctx context.Context, queryId int, params Parameters, maxAge int) (jr *JobOrResult, err error) {
+	rq, err := qs.GetQueryIdTypedContext(ctx, queryId)
+	if err != nil {
+		return nil, err
+	}
+	values, err := params.Encode(rq.Options.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	body := struct {
+		Parameters map[string]interface{} `json:"parameters"`
+		MaxAge     int                    `json:"max_age"`
+	}{values, maxAge}
+	jr = &JobOrResult{}
+	if err = doJSON(ctx, qs.Client, http.MethodPost, qs.Queries(fmt.Sprintf("%d/results", queryId)), nil, body, jr); err != nil {
+		return nil, err
+	}
+	return jr, nil
+}
@@ -0,0 +1,137 @@
+package redash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+const parameterQueryResp = `{
+  "id": 1,
+  "name": "sales",
+  "query": "select * from sales where region = '{{ region }}' and day between '{{ span.start }}' and '{{ span.end }}' limit {{ n }}",
+  "options": {
+    "parameters": [
+      {"name": "region", "title": "Region", "type": "enum", "enumOptions": "east\nwest", "value": "east"},
+      {"name": "span", "title": "Span", "type": "date-range", "value": null},
+      {"name": "n", "title": "Limit", "type": "number", "value": 10},
+      {"name": "tags", "title": "Tags", "type": "query", "queryId": 2, "value": null,
+       "multiValuesOptions": {"prefix": "'", "suffix": "'", "separator": ","}}
+    ]
+  }
+}`
+
+func TestParametersEncode(t *testing.T) {
+	var rq ResponseQuery
+	if err := json.Unmarshal([]byte(parameterQueryResp), &rq); err != nil {
+		t.Fatal(err)
+	}
+	declared := rq.Options.Parameters
+	if len(declared) != 4 || declared[3].MultiValuesOptions == nil || declared[3].QueryId != 2 {
+		t.Fatalf("Parameters is bad, have: %+v", declared)
+	}
+
+	start := time.Date(2017, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2017, 7, 31, 0, 0, 0, 0, time.UTC)
+	values, err := Parameters{
+		"region": EnumParam{"west"},
+		"span":   DateRangeParam{Start: start, End: end},
+		"n":      NumberParam(5),
+		"tags":   QueryParam{"a", "b"},
+	}.Encode(declared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, _ := json.Marshal(values)
+	if want := `{"n":5,"region":"west","span":{"end":"2017-07-31","start":"2017-07-01"},"tags":["a","b"]}`; string(buf) != want {
+		t.Fatalf("Values is not match,\n want: %s,\n have: %s\n", want, buf)
+	}
+
+	cases := []struct {
+		params Parameters
+		name   string
+		want   error
+	}{
+		{Parameters{"nothing": TextParam("x")}, "nothing", ErrUnknownParameter},
+		{Parameters{"n": NumberParam(1)}, "span", ErrMissingParameter},
+		{Parameters{"span": DateRangeParam{}, "n": TextParam("1")}, "n", ErrParameterType},
+		{Parameters{"span": DateRangeParam{Start: end, End: start}}, "span", ErrParameterValue},
+		{Parameters{"span": DateRangeParam{}, "region": EnumParam{"north"}}, "region", ErrParameterValue},
+		{Parameters{"span": DateRangeParam{}, "region": EnumParam{"east", "west"}}, "region", ErrParameterValue},
+		{Parameters{"span": DateRangeParam{}, "tags": QueryParam{}}, "tags", ErrParameterValue},
+		{Parameters{"span": DateParam(start)}, "span", ErrParameterType},
+	}
+	for _, c := range cases {
+		_, err := c.params.Encode(declared)
+		var pe *ParameterError
+		if !errors.As(err, &pe) || pe.Name != c.name || !errors.Is(err, c.want) {
+			t.Errorf("Error is not match, want: %s %v, have: %v", c.name, c.want, err)
+		}
+	}
+}
+
+func TestDateParam(t *testing.T) {
+	d := DateParam(time.Date(2017, 7, 16, 10, 52, 26, 0, time.UTC))
+	cases := map[string]string{
+		ParamDate:                "2017-07-16",
+		ParamDatetimeLocal:       "2017-07-16 10:52",
+		ParamDatetimeWithSeconds: "2017-07-16 10:52:26",
+	}
+	for typ, want := range cases {
+		have, err := d.encode(QueryParameter{Type: typ})
+		if err != nil || have != want {
+			t.Errorf("Date is not match, want: %s, have: %v, %v", want, have, err)
+		}
+	}
+}
+
+func TestPostResultsByQueryId(t *testing.T) {
+
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"GET /api/queries/1":          {body: parameterQueryResp},
+		"POST /api/queries/1/results": {body: jobResp},
+	})
+	qs := QueriesS{client}
+
+	params := Parameters{
+		"span": DateRangeParam{
+			Start: time.Date(2017, 7, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2017, 7, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"tags": QueryParam{"a"},
+	}
+	jr, err := qs.PostResultsByQueryId(1, params, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jr.Job == nil || jr.Job.Status != JobStarted || jr.QueryResult != nil {
+		t.Fatalf("JobOrResult is bad, have: %+v", jr)
+	}
+	if want := `{"parameters":{"n":10,"region":"east","span":{"end":"2017-07-02","start":"2017-07-01"},"tags":["a"]},"max_age":0}`; bodies["POST /api/queries/1/results"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/queries/1/results"])
+	}
+
+	if _, err = qs.PostResultsByQueryId(1, Parameters{}, 0); !errors.Is(err, ErrMissingParameter) {
+		t.Fatalf("Error is not ErrMissingParameter, have: %v", err)
+	}
+}
+
+func TestExecuteQueryId(t *testing.T) {
+
+	client, _ := newMockServer(t, map[string]mockRoute{
+		"GET /api/queries/1":          {body: parameterQueryResp},
+		"POST /api/queries/1/results": {body: queryResultResp},
+	})
+	qs := QueriesS{client}
+
+	params := Parameters{"span": DateRangeParam{}, "tags": QueryParam{"a"}}
+	qr, err := qs.ExecuteQueryId(context.Background(), 1, params, &ExecuteOptions{MaxAge: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qr.Id != 2 {
+		t.Fatalf("QueryResult is bad, have: %+v", qr)
+	}
+}
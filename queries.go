@@ -5,9 +5,12 @@
 package redash
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"strconv"
 )
 
@@ -34,6 +37,23 @@ type FormatQuery struct {
 	Query string `json:"query"`
 }
 
+// Wrap Redash tag with count of tagged queries or dashboards.
+type Tag struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// pagingValues make params of paging listing filtered by tags.
+func pagingValues(pageSize, page int, tags []string) url.Values {
+	values := url.Values{}
+	values.Set("page_size", strconv.Itoa(pageSize))
+	values.Set("page", strconv.Itoa(page))
+	for _, tag := range tags {
+		values.Add("tags", tag)
+	}
+	return values
+}
+
 // Wrap Redash paging response query.
 type PagingResponseQuery struct {
 	Count    int             `json:"count"`
@@ -42,27 +62,67 @@ type PagingResponseQuery struct {
 	Results  []ResponseQuery `json:"results"`
 }
 
+// QuerySchedule is refresh schedule of query. Redash returns it as
+// object, or as string of interval seconds(or time of day) in old
+// versions, both are accepted.
+type QuerySchedule struct {
+	// Interval is seconds between refreshes.
+	Interval  int    `json:"interval"`
+	Time      string `json:"time"`
+	DayOfWeek string `json:"day_of_week"`
+	Until     string `json:"until"`
+}
+
+// UnmarshalJSON accept schedule as object or string(old versions).
+func (qs *QuerySchedule) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		switch interval, err := strconv.Atoi(s); {
+		case s == "":
+			*qs = QuerySchedule{}
+		case err == nil:
+			*qs = QuerySchedule{Interval: interval}
+		default:
+			// time of day is daily schedule.
+			*qs = QuerySchedule{Interval: 24 * 60 * 60, Time: s}
+		}
+		return nil
+	}
+	type querySchedule QuerySchedule
+	var q querySchedule
+	if err := json.Unmarshal(b, &q); err != nil {
+		return err
+	}
+	*qs = QuerySchedule(q)
+	return nil
+}
+
 // Wrap Redash response query.
 type ResponseQuery struct {
-	Id                int     `json:"id"`
-	LatestQueryDataId int     `json:"latest_query_data_id"`
-	Name              string  `json:"name"`
-	Description       string  `json:"description"`
-	Query             string  `json:"query"`
-	QueryHash         string  `json:"query_hash"`
-	Schedule          string  `json:"schedule"`
-	ApiKey            string  `json:"api_key"`
-	IsArchived        bool    `json:"is_archived"`
-	IsDraft           bool    `json:"is_draft"`
-	UpdatedAt         string  `json:"updated_at"`
-	CreatedAt         string  `json:"created_at"`
-	DataSourceId      int     `json:"data_source_id"`
-	Options           Options `json:"options"`
-	Version           int     `json:"version"`
-	UserId            int     `json:"user_id"`
-	LastModifiedById  int     `json:"last_modified_by_id"`
-	RetrivedAt        string  `json:"retrieved_at"`
-	Runtime           int     `json:"runtime"`
+	Id                int    `json:"id"`
+	LatestQueryDataId int    `json:"latest_query_data_id"`
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	Query             string `json:"query"`
+	QueryHash         string `json:"query_hash"`
+	// Schedule is nil if query is not scheduled.
+	Schedule         *QuerySchedule `json:"schedule"`
+	ApiKey           string         `json:"api_key"`
+	IsArchived       bool           `json:"is_archived"`
+	IsDraft          bool           `json:"is_draft"`
+	UpdatedAt        string         `json:"updated_at"`
+	CreatedAt        string         `json:"created_at"`
+	DataSourceId     int            `json:"data_source_id"`
+	Options          QueryOptions   `json:"options"`
+	Version          int            `json:"version"`
+	UserId           int            `json:"user_id"`
+	LastModifiedById int            `json:"last_modified_by_id"`
+	RetrivedAt       string         `json:"retrieved_at"`
+	Runtime          int            `json:"runtime"`
+	Tags             []string       `json:"tags"`
+	IsFavorite       bool           `json:"is_favorite"`
+	// Visualizations is embedded only in response of single query.
+	Visualizations []Visualization `json:"visualizations"`
 }
 
 // Wrap Redash new query.
@@ -73,12 +133,21 @@ type NewQuery struct {
 	Description  string            `json:"description"`
 	Schedule     string            `json:"schedule"`
 	Options      map[string]string `json:"options"`
+	Tags         []string          `json:"tags,omitempty"`
 }
 
-// Wrap Redash row for result data.
-type Row struct {
-	Id   int    `json:"id"`
-	Name string `json:"name"`
+// Wrap Redash query result request, body of POST query_results.
+type QueryResultRequest struct {
+	Query        string `json:"query"`
+	DataSourceId int    `json:"data_source_id"`
+	// MaxAge is max age(seconds) of cached result, 0 means always run.
+	MaxAge int `json:"max_age"`
+	// Parameters is json values by name, made by Parameters.Encode.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// ApplyAutoLimit let Redash add limit to query if data source supports.
+	ApplyAutoLimit bool `json:"apply_auto_limit,omitempty"`
+	// QueryId is id of saved query, needed to run with view only permission.
+	QueryId int `json:"query_id,omitempty"`
 }
 
 // Wrap Redash column for result data.
@@ -126,7 +195,16 @@ type Job struct {
 
 // Wrap Redash api POST format.
 func (qs QueriesS) PostFormat(sql string) (r io.Reader, err error) {
-	resp, err := PostInter(qs.Client, qs.Queries("format"), []byte(fmt.Sprintf(`{"query":"%s"}`, sql)))
+	return qs.PostFormatContext(context.Background(), sql)
+}
+
+// PostFormatContext is PostFormat with context.
+func (qs QueriesS) PostFormatContext(ctx context.Context, sql string) (r io.Reader, err error) {
+	buf, err := marshalJSON(FormatQuery{Query: sql})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := PostInterContext(ctx, qs.Client, qs.Queries("format"), buf)
 	if err != nil {
 		return nil, err
 	} else {
@@ -134,10 +212,33 @@ func (qs QueriesS) PostFormat(sql string) (r io.Reader, err error) {
 	}
 }
 
+// PostFormatTyped is typed variant of PostFormat, decodes response into FormatQuery.
+func (qs QueriesS) PostFormatTyped(sql string) (fq *FormatQuery, err error) {
+	return qs.PostFormatTypedContext(context.Background(), sql)
+}
+
+// PostFormatTypedContext is PostFormatTyped with context.
+func (qs QueriesS) PostFormatTypedContext(ctx context.Context, sql string) (fq *FormatQuery, err error) {
+	r, err := qs.PostFormatContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	fq = &FormatQuery{}
+	if err = decodeBody(r, fq); err != nil {
+		return nil, err
+	}
+	return fq, nil
+}
+
 // Wrap Redash api GET search.
 func (qs QueriesS) GetSearch(q string) (r io.Reader, err error) {
+	return qs.GetSearchContext(context.Background(), q)
+}
+
+// GetSearchContext is GetSearch with context.
+func (qs QueriesS) GetSearchContext(ctx context.Context, q string) (r io.Reader, err error) {
 	params := map[string]string{"q": q}
-	resp, err := GetInter(qs.Client, qs.Queries("search"), params)
+	resp, err := GetInterContext(ctx, qs.Client, qs.Queries("search"), params)
 	if err != nil {
 		return nil, err
 	} else {
@@ -145,9 +246,31 @@ func (qs QueriesS) GetSearch(q string) (r io.Reader, err error) {
 	}
 }
 
+// GetSearchTyped is typed variant of GetSearch, decodes response into []ResponseQuery.
+func (qs QueriesS) GetSearchTyped(q string) (rqs []ResponseQuery, err error) {
+	return qs.GetSearchTypedContext(context.Background(), q)
+}
+
+// GetSearchTypedContext is GetSearchTyped with context.
+func (qs QueriesS) GetSearchTypedContext(ctx context.Context, q string) (rqs []ResponseQuery, err error) {
+	r, err := qs.GetSearchContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if err = decodeBody(r, &rqs); err != nil {
+		return nil, err
+	}
+	return rqs, nil
+}
+
 // Wrap Redash api GET recent.
 func (qs QueriesS) GetRecent() (r io.Reader, err error) {
-	resp, err := GetInter(qs.Client, qs.Queries("recent"), nil)
+	return qs.GetRecentContext(context.Background())
+}
+
+// GetRecentContext is GetRecent with context.
+func (qs QueriesS) GetRecentContext(ctx context.Context) (r io.Reader, err error) {
+	resp, err := GetInterContext(ctx, qs.Client, qs.Queries("recent"), nil)
 	if err != nil {
 		return nil, err
 	} else {
@@ -155,10 +278,32 @@ func (qs QueriesS) GetRecent() (r io.Reader, err error) {
 	}
 }
 
+// GetRecentTyped is typed variant of GetRecent, decodes response into []ResponseQuery.
+func (qs QueriesS) GetRecentTyped() (rqs []ResponseQuery, err error) {
+	return qs.GetRecentTypedContext(context.Background())
+}
+
+// GetRecentTypedContext is GetRecentTyped with context.
+func (qs QueriesS) GetRecentTypedContext(ctx context.Context) (rqs []ResponseQuery, err error) {
+	r, err := qs.GetRecentContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = decodeBody(r, &rqs); err != nil {
+		return nil, err
+	}
+	return rqs, nil
+}
+
 // Wrap Redash api GET my.
 func (qs QueriesS) GetMy(pageSize, page int) (r io.Reader, err error) {
+	return qs.GetMyContext(context.Background(), pageSize, page)
+}
+
+// GetMyContext is GetMy with context.
+func (qs QueriesS) GetMyContext(ctx context.Context, pageSize, page int) (r io.Reader, err error) {
 	params := map[string]string{"page_size": strconv.Itoa(pageSize), "page": strconv.Itoa(page)}
-	resp, err := GetInter(qs.Client, qs.Queries("my"), params)
+	resp, err := GetInterContext(ctx, qs.Client, qs.Queries("my"), params)
 	if err != nil {
 		return nil, err
 	} else {
@@ -166,13 +311,36 @@ func (qs QueriesS) GetMy(pageSize, page int) (r io.Reader, err error) {
 	}
 }
 
+// GetMyTyped is typed variant of GetMy, decodes response into PagingResponseQuery.
+func (qs QueriesS) GetMyTyped(pageSize, page int) (prq *PagingResponseQuery, err error) {
+	return qs.GetMyTypedContext(context.Background(), pageSize, page)
+}
+
+// GetMyTypedContext is GetMyTyped with context.
+func (qs QueriesS) GetMyTypedContext(ctx context.Context, pageSize, page int) (prq *PagingResponseQuery, err error) {
+	r, err := qs.GetMyContext(ctx, pageSize, page)
+	if err != nil {
+		return nil, err
+	}
+	prq = &PagingResponseQuery{}
+	if err = decodeBody(r, prq); err != nil {
+		return nil, err
+	}
+	return prq, nil
+}
+
 // Wrap Redash api POST queries.
 func (qs QueriesS) PostQuery(newQuery NewQuery) (res io.Reader, err error) {
+	return qs.PostQueryContext(context.Background(), newQuery)
+}
+
+// PostQueryContext is PostQuery with context.
+func (qs QueriesS) PostQueryContext(ctx context.Context, newQuery NewQuery) (res io.Reader, err error) {
 	newQueryBuf, err := json.Marshal(newQuery)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := PostInter(qs.Client, qs.Queries(""), newQueryBuf)
+	resp, err := PostInterContext(ctx, qs.Client, qs.Queries(""), newQueryBuf)
 	if err != nil {
 		return nil, err
 	} else {
@@ -180,10 +348,33 @@ func (qs QueriesS) PostQuery(newQuery NewQuery) (res io.Reader, err error) {
 	}
 }
 
+// PostQueryTyped is typed variant of PostQuery, decodes response into ResponseQuery.
+func (qs QueriesS) PostQueryTyped(newQuery NewQuery) (rq *ResponseQuery, err error) {
+	return qs.PostQueryTypedContext(context.Background(), newQuery)
+}
+
+// PostQueryTypedContext is PostQueryTyped with context.
+func (qs QueriesS) PostQueryTypedContext(ctx context.Context, newQuery NewQuery) (rq *ResponseQuery, err error) {
+	r, err := qs.PostQueryContext(ctx, newQuery)
+	if err != nil {
+		return nil, err
+	}
+	rq = &ResponseQuery{}
+	if err = decodeBody(r, rq); err != nil {
+		return nil, err
+	}
+	return rq, nil
+}
+
 // Wrap Redash api GET queries.
 func (qs QueriesS) GetQuery(pageSize, page int) (r io.Reader, err error) {
+	return qs.GetQueryContext(context.Background(), pageSize, page)
+}
+
+// GetQueryContext is GetQuery with context.
+func (qs QueriesS) GetQueryContext(ctx context.Context, pageSize, page int) (r io.Reader, err error) {
 	params := map[string]string{"page_size": strconv.Itoa(pageSize), "page": strconv.Itoa(page)}
-	resp, err := GetInter(qs.Client, qs.Queries(""), params)
+	resp, err := GetInterContext(ctx, qs.Client, qs.Queries(""), params)
 	if err != nil {
 		return nil, err
 	} else {
@@ -191,9 +382,32 @@ func (qs QueriesS) GetQuery(pageSize, page int) (r io.Reader, err error) {
 	}
 }
 
+// GetQueryTyped is typed variant of GetQuery, decodes response into PagingResponseQuery.
+func (qs QueriesS) GetQueryTyped(pageSize, page int) (prq *PagingResponseQuery, err error) {
+	return qs.GetQueryTypedContext(context.Background(), pageSize, page)
+}
+
+// GetQueryTypedContext is GetQueryTyped with context.
+func (qs QueriesS) GetQueryTypedContext(ctx context.Context, pageSize, page int) (prq *PagingResponseQuery, err error) {
+	r, err := qs.GetQueryContext(ctx, pageSize, page)
+	if err != nil {
+		return nil, err
+	}
+	prq = &PagingResponseQuery{}
+	if err = decodeBody(r, prq); err != nil {
+		return nil, err
+	}
+	return prq, nil
+}
+
 // Wrap Redash api POST refresh.
 func (qs QueriesS) PostRefresh(queryId int) (r io.Reader, err error) {
-	resp, err := PostInter(qs.Client, qs.Queries(fmt.Sprintf("%d/refresh", queryId)), nil)
+	return qs.PostRefreshContext(context.Background(), queryId)
+}
+
+// PostRefreshContext is PostRefresh with context.
+func (qs QueriesS) PostRefreshContext(ctx context.Context, queryId int) (r io.Reader, err error) {
+	resp, err := PostInterContext(ctx, qs.Client, qs.Queries(fmt.Sprintf("%d/refresh", queryId)), nil)
 	if err != nil {
 		return nil, err
 	} else {
@@ -201,9 +415,32 @@ func (qs QueriesS) PostRefresh(queryId int) (r io.Reader, err error) {
 	}
 }
 
+// PostRefreshTyped is typed variant of PostRefresh, decodes response into Job.
+func (qs QueriesS) PostRefreshTyped(queryId int) (job *Job, err error) {
+	return qs.PostRefreshTypedContext(context.Background(), queryId)
+}
+
+// PostRefreshTypedContext is PostRefreshTyped with context.
+func (qs QueriesS) PostRefreshTypedContext(ctx context.Context, queryId int) (job *Job, err error) {
+	r, err := qs.PostRefreshContext(ctx, queryId)
+	if err != nil {
+		return nil, err
+	}
+	job = &Job{}
+	if err = decodeBody(r, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
 // Wrap Redash api POST fork.
 func (qs QueriesS) PostFork(queryId int) (r io.Reader, err error) {
-	resp, err := PostInter(qs.Client, qs.Queries(fmt.Sprintf("%d/fork", queryId)), nil)
+	return qs.PostForkContext(context.Background(), queryId)
+}
+
+// PostForkContext is PostFork with context.
+func (qs QueriesS) PostForkContext(ctx context.Context, queryId int) (r io.Reader, err error) {
+	resp, err := PostInterContext(ctx, qs.Client, qs.Queries(fmt.Sprintf("%d/fork", queryId)), nil)
 	if err != nil {
 		return nil, err
 	} else {
@@ -211,13 +448,36 @@ func (qs QueriesS) PostFork(queryId int) (r io.Reader, err error) {
 	}
 }
 
+// PostForkTyped is typed variant of PostFork, decodes response into ResponseQuery.
+func (qs QueriesS) PostForkTyped(queryId int) (rq *ResponseQuery, err error) {
+	return qs.PostForkTypedContext(context.Background(), queryId)
+}
+
+// PostForkTypedContext is PostForkTyped with context.
+func (qs QueriesS) PostForkTypedContext(ctx context.Context, queryId int) (rq *ResponseQuery, err error) {
+	r, err := qs.PostForkContext(ctx, queryId)
+	if err != nil {
+		return nil, err
+	}
+	rq = &ResponseQuery{}
+	if err = decodeBody(r, rq); err != nil {
+		return nil, err
+	}
+	return rq, nil
+}
+
 // Wrap Redash api POST queries.
 func (qs QueriesS) PostQueryId(queryId int, newQuery NewQuery) (r io.Reader, err error) {
+	return qs.PostQueryIdContext(context.Background(), queryId, newQuery)
+}
+
+// PostQueryIdContext is PostQueryId with context.
+func (qs QueriesS) PostQueryIdContext(ctx context.Context, queryId int, newQuery NewQuery) (r io.Reader, err error) {
 	newQueryBuf, err := json.Marshal(newQuery)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := PostInter(qs.Client, qs.Queries(strconv.Itoa(queryId)), newQueryBuf)
+	resp, err := PostInterContext(ctx, qs.Client, qs.Queries(strconv.Itoa(queryId)), newQueryBuf)
 	if err != nil {
 		return nil, err
 	} else {
@@ -225,9 +485,32 @@ func (qs QueriesS) PostQueryId(queryId int, newQuery NewQuery) (r io.Reader, err
 	}
 }
 
+// PostQueryIdTyped is typed variant of PostQueryId, decodes response into ResponseQuery.
+func (qs QueriesS) PostQueryIdTyped(queryId int, newQuery NewQuery) (rq *ResponseQuery, err error) {
+	return qs.PostQueryIdTypedContext(context.Background(), queryId, newQuery)
+}
+
+// PostQueryIdTypedContext is PostQueryIdTyped with context.
+func (qs QueriesS) PostQueryIdTypedContext(ctx context.Context, queryId int, newQuery NewQuery) (rq *ResponseQuery, err error) {
+	r, err := qs.PostQueryIdContext(ctx, queryId, newQuery)
+	if err != nil {
+		return nil, err
+	}
+	rq = &ResponseQuery{}
+	if err = decodeBody(r, rq); err != nil {
+		return nil, err
+	}
+	return rq, nil
+}
+
 // Wrap Redash api DELETE queries.
 func (qs QueriesS) DeleteQuery(queryId int) (r io.Reader, err error) {
-	resp, err := DeleteInter(qs.Client, qs.Queries(strconv.Itoa(queryId)), nil)
+	return qs.DeleteQueryContext(context.Background(), queryId)
+}
+
+// DeleteQueryContext is DeleteQuery with context.
+func (qs QueriesS) DeleteQueryContext(ctx context.Context, queryId int) (r io.Reader, err error) {
+	resp, err := DeleteInterContext(ctx, qs.Client, qs.Queries(strconv.Itoa(queryId)), nil)
 	if err != nil {
 		return nil, err
 	} else {
@@ -235,9 +518,28 @@ func (qs QueriesS) DeleteQuery(queryId int) (r io.Reader, err error) {
 	}
 }
 
+// DeleteQueryTyped is typed variant of DeleteQuery, closes response and return error only.
+func (qs QueriesS) DeleteQueryTyped(queryId int) (err error) {
+	return qs.DeleteQueryTypedContext(context.Background(), queryId)
+}
+
+// DeleteQueryTypedContext is DeleteQueryTyped with context.
+func (qs QueriesS) DeleteQueryTypedContext(ctx context.Context, queryId int) (err error) {
+	r, err := qs.DeleteQueryContext(ctx, queryId)
+	if err != nil {
+		return err
+	}
+	return decodeBody(r, nil)
+}
+
 // Wrap Redash api GET queries/${query id}.
 func (qs QueriesS) GetQueryId(queryId int) (r io.Reader, err error) {
-	resp, err := GetInter(qs.Client, qs.Queries(strconv.Itoa(queryId)), nil)
+	return qs.GetQueryIdContext(context.Background(), queryId)
+}
+
+// GetQueryIdContext is GetQueryId with context.
+func (qs QueriesS) GetQueryIdContext(ctx context.Context, queryId int) (r io.Reader, err error) {
+	resp, err := GetInterContext(ctx, qs.Client, qs.Queries(strconv.Itoa(queryId)), nil)
 	if err != nil {
 		return nil, err
 	} else {
@@ -245,9 +547,64 @@ func (qs QueriesS) GetQueryId(queryId int) (r io.Reader, err error) {
 	}
 }
 
+// GetQueryIdTyped is typed variant of GetQueryId, decodes response into ResponseQuery.
+func (qs QueriesS) GetQueryIdTyped(queryId int) (rq *ResponseQuery, err error) {
+	return qs.GetQueryIdTypedContext(context.Background(), queryId)
+}
+
+// GetQueryIdTypedContext is GetQueryIdTyped with context.
+func (qs QueriesS) GetQueryIdTypedContext(ctx context.Context, queryId int) (rq *ResponseQuery, err error) {
+	r, err := qs.GetQueryIdContext(ctx, queryId)
+	if err != nil {
+		return nil, err
+	}
+	rq = &ResponseQuery{}
+	if err = decodeBody(r, rq); err != nil {
+		return nil, err
+	}
+	return rq, nil
+}
+
 // Wrap Redash api POST query_results.
 func (qs QueriesS) PostQueryResult(query string, maxAge, dataSourceId int) (r io.Reader, err error) {
-	resp, err := PostInter(qs.Client, "/api/query_results", []byte(fmt.Sprintf(`{"query":"%s","max_age":%d,"data_sourece_id":%d}`, query, maxAge, dataSourceId)))
+	return qs.PostQueryResultContext(context.Background(), query, maxAge, dataSourceId)
+}
+
+// PostQueryResultContext is PostQueryResult with context.
+func (qs QueriesS) PostQueryResultContext(ctx context.Context, query string, maxAge, dataSourceId int) (r io.Reader, err error) {
+	return qs.PostQueryResultRequestContext(ctx, QueryResultRequest{Query: query, MaxAge: maxAge, DataSourceId: dataSourceId})
+}
+
+// PostQueryResultTyped is typed variant of PostQueryResult, decodes response into Job.
+func (qs QueriesS) PostQueryResultTyped(query string, maxAge, dataSourceId int) (job *Job, err error) {
+	return qs.PostQueryResultTypedContext(context.Background(), query, maxAge, dataSourceId)
+}
+
+// PostQueryResultTypedContext is PostQueryResultTyped with context.
+func (qs QueriesS) PostQueryResultTypedContext(ctx context.Context, query string, maxAge, dataSourceId int) (job *Job, err error) {
+	r, err := qs.PostQueryResultContext(ctx, query, maxAge, dataSourceId)
+	if err != nil {
+		return nil, err
+	}
+	job = &Job{}
+	if err = decodeBody(r, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Wrap Redash api POST query_results with full request.
+func (qs QueriesS) PostQueryResultRequest(req QueryResultRequest) (r io.Reader, err error) {
+	return qs.PostQueryResultRequestContext(context.Background(), req)
+}
+
+// PostQueryResultRequestContext is PostQueryResultRequest with context.
+func (qs QueriesS) PostQueryResultRequestContext(ctx context.Context, req QueryResultRequest) (r io.Reader, err error) {
+	buf, err := marshalJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := PostInterContext(ctx, qs.Client, "/api/query_results", buf)
 	if err != nil {
 		return nil, err
 	} else {
@@ -255,9 +612,33 @@ func (qs QueriesS) PostQueryResult(query string, maxAge, dataSourceId int) (r io
 	}
 }
 
+// PostQueryResultRequestTyped is typed variant of PostQueryResultRequest,
+// decodes response into JobOrResult as cached result may be returned.
+func (qs QueriesS) PostQueryResultRequestTyped(req QueryResultRequest) (jr *JobOrResult, err error) {
+	return qs.PostQueryResultRequestTypedContext(context.Background(), req)
+}
+
+// PostQueryResultRequestTypedContext is PostQueryResultRequestTyped with context.
+func (qs QueriesS) PostQueryResultRequestTypedContext(ctx context.Context, req QueryResultRequest) (jr *JobOrResult, err error) {
+	r, err := qs.PostQueryResultRequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	jr = &JobOrResult{}
+	if err = decodeBody(r, jr); err != nil {
+		return nil, err
+	}
+	return jr, nil
+}
+
 // Wrap Redash api GET ${query id}/results/${query resut id}.${filetype}
 func (qs QueriesS) GetResultsById(queryId, queryResultId int, filetype string) (r io.Reader, err error) {
-	resp, err := GetInter(qs.Client, qs.Queries(fmt.Sprintf("%d/results/%d.%s", queryId, queryResultId, filetype)), nil)
+	return qs.GetResultsByIdContext(context.Background(), queryId, queryResultId, filetype)
+}
+
+// GetResultsByIdContext is GetResultsById with context.
+func (qs QueriesS) GetResultsByIdContext(ctx context.Context, queryId, queryResultId int, filetype string) (r io.Reader, err error) {
+	resp, err := GetInterContext(ctx, qs.Client, qs.Queries(fmt.Sprintf("%d/results/%d.%s", queryId, queryResultId, filetype)), nil)
 	if err != nil {
 		return nil, err
 	} else {
@@ -265,9 +646,32 @@ func (qs QueriesS) GetResultsById(queryId, queryResultId int, filetype string) (
 	}
 }
 
+// GetResultsByIdTyped is typed variant of GetResultsById, decodes response into Result.
+func (qs QueriesS) GetResultsByIdTyped(queryId, queryResultId int) (result *Result, err error) {
+	return qs.GetResultsByIdTypedContext(context.Background(), queryId, queryResultId)
+}
+
+// GetResultsByIdTypedContext is GetResultsByIdTyped with context.
+func (qs QueriesS) GetResultsByIdTypedContext(ctx context.Context, queryId, queryResultId int) (result *Result, err error) {
+	r, err := qs.GetResultsByIdContext(ctx, queryId, queryResultId, "json")
+	if err != nil {
+		return nil, err
+	}
+	result = &Result{}
+	if err = decodeBody(r, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // Wrap Redash api GET ${query id}/results.${filetype}.
 func (qs QueriesS) GetResultsByQueryId(queryId int, filetype string) (r io.Reader, err error) {
-	resp, err := GetInter(qs.Client, qs.Queries(fmt.Sprintf("%d/results.%s", queryId, filetype)), nil)
+	return qs.GetResultsByQueryIdContext(context.Background(), queryId, filetype)
+}
+
+// GetResultsByQueryIdContext is GetResultsByQueryId with context.
+func (qs QueriesS) GetResultsByQueryIdContext(ctx context.Context, queryId int, filetype string) (r io.Reader, err error) {
+	resp, err := GetInterContext(ctx, qs.Client, qs.Queries(fmt.Sprintf("%d/results.%s", queryId, filetype)), nil)
 	if err != nil {
 		return nil, err
 	} else {
@@ -275,9 +679,32 @@ func (qs QueriesS) GetResultsByQueryId(queryId int, filetype string) (r io.Reade
 	}
 }
 
+// GetResultsByQueryIdTyped is typed variant of GetResultsByQueryId, decodes response into Result.
+func (qs QueriesS) GetResultsByQueryIdTyped(queryId int) (result *Result, err error) {
+	return qs.GetResultsByQueryIdTypedContext(context.Background(), queryId)
+}
+
+// GetResultsByQueryIdTypedContext is GetResultsByQueryIdTyped with context.
+func (qs QueriesS) GetResultsByQueryIdTypedContext(ctx context.Context, queryId int) (result *Result, err error) {
+	r, err := qs.GetResultsByQueryIdContext(ctx, queryId, "json")
+	if err != nil {
+		return nil, err
+	}
+	result = &Result{}
+	if err = decodeBody(r, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // Wrap Redash api GET querie_results.
 func (qs QueriesS) GetQueryResults(queryResultId int) (r io.Reader, err error) {
-	resp, err := GetInter(qs.Client, fmt.Sprintf("/api/query_results/%d", queryResultId), nil)
+	return qs.GetQueryResultsContext(context.Background(), queryResultId)
+}
+
+// GetQueryResultsContext is GetQueryResults with context.
+func (qs QueriesS) GetQueryResultsContext(ctx context.Context, queryResultId int) (r io.Reader, err error) {
+	resp, err := GetInterContext(ctx, qs.Client, fmt.Sprintf("/api/query_results/%d", queryResultId), nil)
 	if err != nil {
 		return nil, err
 	} else {
@@ -285,9 +712,32 @@ func (qs QueriesS) GetQueryResults(queryResultId int) (r io.Reader, err error) {
 	}
 }
 
+// GetQueryResultsTyped is typed variant of GetQueryResults, decodes response into Result.
+func (qs QueriesS) GetQueryResultsTyped(queryResultId int) (result *Result, err error) {
+	return qs.GetQueryResultsTypedContext(context.Background(), queryResultId)
+}
+
+// GetQueryResultsTypedContext is GetQueryResultsTyped with context.
+func (qs QueriesS) GetQueryResultsTypedContext(ctx context.Context, queryResultId int) (result *Result, err error) {
+	r, err := qs.GetQueryResultsContext(ctx, queryResultId)
+	if err != nil {
+		return nil, err
+	}
+	result = &Result{}
+	if err = decodeBody(r, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // Wrap Redash api DELETE jobs.
 func (qs QueriesS) DeleteJog(jobId string) (r io.Reader, err error) {
-	resp, err := DeleteInter(qs.Client, fmt.Sprintf("/api/jobs/%s", jobId), nil)
+	return qs.DeleteJogContext(context.Background(), jobId)
+}
+
+// DeleteJogContext is DeleteJog with context.
+func (qs QueriesS) DeleteJogContext(ctx context.Context, jobId string) (r io.Reader, err error) {
+	resp, err := DeleteInterContext(ctx, qs.Client, fmt.Sprintf("/api/jobs/%s", jobId), nil)
 	if err != nil {
 		return nil, err
 	} else {
@@ -295,12 +745,113 @@ func (qs QueriesS) DeleteJog(jobId string) (r io.Reader, err error) {
 	}
 }
 
+// DeleteJobTyped is typed variant of DeleteJog, closes response and return error only.
+func (qs QueriesS) DeleteJobTyped(jobId string) (err error) {
+	return qs.DeleteJobTypedContext(context.Background(), jobId)
+}
+
+// DeleteJobTypedContext is DeleteJobTyped with context.
+func (qs QueriesS) DeleteJobTypedContext(ctx context.Context, jobId string) (err error) {
+	r, err := qs.DeleteJogContext(ctx, jobId)
+	if err != nil {
+		return err
+	}
+	return decodeBody(r, nil)
+}
+
 // Wrap Redash api GET jobs.
 func (qs QueriesS) GetJob(jobId string) (r io.Reader, err error) {
-	resp, err := GetInter(qs.Client, fmt.Sprintf("/api/jobs/%s", jobId), nil)
+	return qs.GetJobContext(context.Background(), jobId)
+}
+
+// GetJobContext is GetJob with context.
+func (qs QueriesS) GetJobContext(ctx context.Context, jobId string) (r io.Reader, err error) {
+	resp, err := GetInterContext(ctx, qs.Client, fmt.Sprintf("/api/jobs/%s", jobId), nil)
 	if err != nil {
 		return nil, err
 	} else {
 		return resp.Body, nil
 	}
 }
+
+// GetJobTyped is typed variant of GetJob, decodes response into Job.
+func (qs QueriesS) GetJobTyped(jobId string) (job *Job, err error) {
+	return qs.GetJobTypedContext(context.Background(), jobId)
+}
+
+// GetJobTypedContext is GetJobTyped with context.
+func (qs QueriesS) GetJobTypedContext(ctx context.Context, jobId string) (job *Job, err error) {
+	r, err := qs.GetJobContext(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+	job = &Job{}
+	if err = decodeBody(r, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Wrap Redash api GET queries with tags, queries having all of tags.
+func (qs QueriesS) GetQueryByTags(pageSize, page int, tags ...string) (prq *PagingResponseQuery, err error) {
+	return qs.GetQueryByTagsContext(context.Background(), pageSize, page, tags...)
+}
+
+// GetQueryByTagsContext is GetQueryByTags with context.
+func (qs QueriesS) GetQueryByTagsContext(ctx context.Context, pageSize, page int, tags ...string) (prq *PagingResponseQuery, err error) {
+	prq = &PagingResponseQuery{}
+	if err = getValuesJSON(ctx, qs.Client, qs.Queries(""), pagingValues(pageSize, page, tags), prq); err != nil {
+		return nil, err
+	}
+	return prq, nil
+}
+
+// Wrap Redash api GET queries/tags.
+func (qs QueriesS) GetTags() (tags []Tag, err error) {
+	return qs.GetTagsContext(context.Background())
+}
+
+// GetTagsContext is GetTags with context.
+func (qs QueriesS) GetTagsContext(ctx context.Context) (tags []Tag, err error) {
+	var res struct {
+		Tags []Tag `json:"tags"`
+	}
+	if err = doJSON(ctx, qs.Client, http.MethodGet, qs.Queries("tags"), nil, nil, &res); err != nil {
+		return nil, err
+	}
+	return res.Tags, nil
+}
+
+// Wrap Redash api GET queries/favorites.
+func (qs QueriesS) GetFavorites(pageSize, page int) (prq *PagingResponseQuery, err error) {
+	return qs.GetFavoritesContext(context.Background(), pageSize, page)
+}
+
+// GetFavoritesContext is GetFavorites with context.
+func (qs QueriesS) GetFavoritesContext(ctx context.Context, pageSize, page int) (prq *PagingResponseQuery, err error) {
+	prq = &PagingResponseQuery{}
+	if err = getValuesJSON(ctx, qs.Client, qs.Queries("favorites"), pagingValues(pageSize, page, nil), prq); err != nil {
+		return nil, err
+	}
+	return prq, nil
+}
+
+// Wrap Redash api POST favorite.
+func (qs QueriesS) PostFavorite(queryId int) (err error) {
+	return qs.PostFavoriteContext(context.Background(), queryId)
+}
+
+// PostFavoriteContext is PostFavorite with context.
+func (qs QueriesS) PostFavoriteContext(ctx context.Context, queryId int) (err error) {
+	return doJSON(ctx, qs.Client, http.MethodPost, qs.Queries(fmt.Sprintf("%d/favorite", queryId)), nil, nil, nil)
+}
+
+// Wrap Redash api DELETE favorite.
+func (qs QueriesS) DeleteFavorite(queryId int) (err error) {
+	return qs.DeleteFavoriteContext(context.Background(), queryId)
+}
+
+// DeleteFavoriteContext is DeleteFavorite with context.
+func (qs QueriesS) DeleteFavoriteContext(ctx context.Context, queryId int) (err error) {
+	return doJSON(ctx, qs.Client, http.MethodDelete, qs.Queries(fmt.Sprintf("%d/favorite", queryId)), nil, nil, nil)
+}
@@ -57,6 +57,10 @@ const queryResp = `{
 
 var queryResps = fmt.Sprintf(`[%s]`, queryResp)
 
+// scheduledQueryResp is queryResp with schedule object of Redash 7 or later.
+var scheduledQueryResp = strings.Replace(queryResp, `"schedule": null`,
+	`"schedule": {"interval": 3600, "time": null, "day_of_week": null, "until": null}`, 1)
+
 var pagingResp = fmt.Sprintf(`{
   "count": 1,
   "page": 1,
@@ -309,7 +313,7 @@ func init() {
 					}
 				}
 				w.Header().Set("Content-Type", "application/json")
-				fmt.Fprintf(w, rs)
+				fmt.Fprint(w, rs)
 			})
 	}
 	tgs = httptest.NewServer(mux)
@@ -393,10 +397,10 @@ func TestGetMy(t *testing.T) {
 		t.Error(err)
 	}
 	if responsed.PageSize != pageSize {
-		t.Fatalf("PageSize was not match,\n want: %q,\n have: %q\n", pageSize, responsed.PageSize)
+		t.Fatalf("PageSize was not match,\n want: %v,\n have: %v\n", pageSize, responsed.PageSize)
 	}
 	if responsed.Page != page {
-		t.Fatalf("Page was not match,\n want: %q,\n have: %q\n", page, responsed.Page)
+		t.Fatalf("Page was not match,\n want: %v,\n have: %v\n", page, responsed.Page)
 	}
 }
 
@@ -422,7 +426,7 @@ func TestPostQuery(t *testing.T) {
 		t.Error(err)
 	}
 	if responsed.DataSourceId != dataSourceId {
-		t.Fatalf("DataSourceId is not match,\n want: %q,\n have: %q\n", dataSourceId, responsed.DataSourceId)
+		t.Fatalf("DataSourceId is not match,\n want: %v,\n have: %v\n", dataSourceId, responsed.DataSourceId)
 	}
 	if responsed.Query != query {
 		t.Fatalf("Query is not match,\n want: %q,\n have: %q\n", query, responsed.Query)
@@ -448,10 +452,10 @@ func TestGetQuery(t *testing.T) {
 		t.Error(err)
 	}
 	if responsed.PageSize != pageSize {
-		t.Fatalf("PageSize was not match,\n want: %q,\n have: %q\n", pageSize, responsed.PageSize)
+		t.Fatalf("PageSize was not match,\n want: %v,\n have: %v\n", pageSize, responsed.PageSize)
 	}
 	if responsed.Page != page {
-		t.Fatalf("Page was not match,\n want: %q,\n have: %q\n", page, responsed.Page)
+		t.Fatalf("Page was not match,\n want: %v,\n have: %v\n", page, responsed.Page)
 	}
 }
 
@@ -470,10 +474,10 @@ func TestPostRefresh(t *testing.T) {
 		t.Error(err)
 	}
 	if job.Job.Status != 2 {
-		t.Fatalf("Job status is not match,\n want: %q,\n have: %q\n", 2, job.Job.Status)
+		t.Fatalf("Job status is not match,\n want: %v,\n have: %v\n", 2, job.Job.Status)
 	}
 	if job.Job.QueryResultId != 0 {
-		t.Fatalf("Query result id is not match,\n want: %q,\n have: %q\n", "", job.Job.QueryResultId)
+		t.Fatalf("Query result id is not match,\n want: %v,\n have: %v\n", "", job.Job.QueryResultId)
 	}
 }
 
@@ -494,7 +498,7 @@ func TestPostFork(t *testing.T) {
 		t.Error(err)
 	}
 	if responsed.Id == queryId {
-		t.Fatalf("Query id is not updated,\n want: != %q,\n have: %q\n", queryId, responsed.Id)
+		t.Fatalf("Query id is not updated,\n want: != %v,\n have: %v\n", queryId, responsed.Id)
 	}
 	if responsed.Name != forkedName {
 		t.Fatalf("Name is not match,\n want: %q,\n have: %q\n", forkedName, responsed.Name)
@@ -524,7 +528,7 @@ func TestPostQueryId(t *testing.T) {
 		t.Error(err)
 	}
 	if responsed.Id != queryId {
-		t.Fatalf("Query id is not match,\n want: %q,\n have: %q\n", queryId, responsed.Id)
+		t.Fatalf("Query id is not match,\n want: %v,\n have: %v\n", queryId, responsed.Id)
 	}
 	if responsed.Query != query {
 		t.Fatalf("Query is not match,\n want: %q,\n have: %q\n", query, responsed.Query)
@@ -567,7 +571,7 @@ func TestGetQueryId(t *testing.T) {
 		t.Fatalf("Format is not json,\n err: %q,\n have: %q\n", err, buf)
 	}
 	if responsed.Id != queryId {
-		t.Fatalf("Query id is not match,\n want: %q,\n have: %q\n", queryId, responsed.Id)
+		t.Fatalf("Query id is not match,\n want: %v,\n have: %v\n", queryId, responsed.Id)
 	}
 	if responsed.Name != name {
 		t.Fatalf("Query name is not match,\n want: %q,\n have: %q\n", name, responsed.Name)
@@ -592,10 +596,10 @@ func TestPostQueryResult(t *testing.T) {
 		t.Error(err)
 	}
 	if respJob.Job.Status != 2 {
-		t.Fatalf("Job status is error,\n want: %q,\n have: %q\n", 2, respJob.Job.Status)
+		t.Fatalf("Job status is error,\n want: %v,\n have: %v\n", 2, respJob.Job.Status)
 	}
 	if respJob.Job.QueryResultId != 0 {
-		t.Fatalf("QueryResultId is not null,\n want: %q,\n have: %q\n", "null", respJob.Job.QueryResultId)
+		t.Fatalf("QueryResultId is not null,\n want: %v,\n have: %v\n", "null", respJob.Job.QueryResultId)
 	}
 	if respJob.Job.Error != "" {
 		t.Fatalf("Error is not empty,\n want: %q,\n have: %q\n", "", respJob.Job.Error)
@@ -619,10 +623,10 @@ func TestGetResultsById(t *testing.T) {
 		t.Fatalf("Format is not json,\n err: %q,\n have: %q\n", err, buf)
 	}
 	if results.QueryResult.Id != queryResultId {
-		t.Fatalf("Query result id is not match,\n want: %q,\n have: %q\n", queryResultId, results.QueryResult.Id)
+		t.Fatalf("Query result id is not match,\n want: %v,\n have: %v\n", queryResultId, results.QueryResult.Id)
 	}
 	if len(results.QueryResult.Data.Rows) == 0 {
-		t.Fatalf("Rows num is bad,\n want: %q,\n have: %q\n", 2, len(results.QueryResult.Data.Rows))
+		t.Fatalf("Rows num is bad,\n want: %v,\n have: %v\n", 2, len(results.QueryResult.Data.Rows))
 	}
 }
 
@@ -642,7 +646,7 @@ func TestGetResultsByQueryId(t *testing.T) {
 		t.Fatalf("Format is not json,\n err: %q,\n hav	e: %q\n", err, buf)
 	}
 	if len(results.QueryResult.Data.Rows) == 0 {
-		t.Fatalf("Rows num is bad,\n want: %q,\n have: %q\n", 2, len(results.QueryResult.Data.Rows))
+		t.Fatalf("Rows num is bad,\n want: %v,\n have: %v\n", 2, len(results.QueryResult.Data.Rows))
 	}
 }
 
@@ -661,10 +665,10 @@ func TestGetQueryResults(t *testing.T) {
 		t.Fatalf("Format is not json,\n err: %q,\n have: %q\n", err, buf)
 	}
 	if results.QueryResult.Id != queryResultId {
-		t.Fatalf("Query result id is not match,\n want: %q,\n have: %q\n", queryResultId, results.QueryResult.Id)
+		t.Fatalf("Query result id is not match,\n want: %v,\n have: %v\n", queryResultId, results.QueryResult.Id)
 	}
 	if len(results.QueryResult.Data.Rows) == 0 {
-		t.Fatalf("Rows num is bad,\n want: %q,\n have: %q\n", 2, len(results.QueryResult.Data.Rows))
+		t.Fatalf("Rows num is bad,\n want: %v,\n have: %v\n", 2, len(results.QueryResult.Data.Rows))
 	}
 }
 
@@ -706,3 +710,262 @@ func TestGetJob(t *testing.T) {
 		t.Fatalf("Error is not empty,\n want: %q,\n have: %q\n", "", job.Job.Error)
 	}
 }
+
+func TestQuerySchedule(t *testing.T) {
+
+	cases := []struct {
+		in   string
+		want QuerySchedule
+	}{
+		{`{"interval": 604800, "time": "02:00", "day_of_week": "Monday", "until": "2017-12-31"}`,
+			QuerySchedule{Interval: 604800, Time: "02:00", DayOfWeek: "Monday", Until: "2017-12-31"}},
+		{`{"interval": 3600, "time": null, "day_of_week": null, "until": null}`, QuerySchedule{Interval: 3600}},
+		{`"3600"`, QuerySchedule{Interval: 3600}},
+		{`"02:00"`, QuerySchedule{Interval: 86400, Time: "02:00"}},
+		{`""`, QuerySchedule{}},
+	}
+	for _, c := range cases {
+		var have QuerySchedule
+		if err := json.Unmarshal([]byte(c.in), &have); err != nil {
+			t.Fatal(err)
+		}
+		if have != c.want {
+			t.Fatalf("Schedule is not match,\n want: %+v,\n have: %+v\n", c.want, have)
+		}
+	}
+
+	var rq ResponseQuery
+	if err := json.Unmarshal([]byte(scheduledQueryResp), &rq); err != nil {
+		t.Fatal(err)
+	}
+	if rq.Schedule == nil || rq.Schedule.Interval != 3600 {
+		t.Fatalf("Schedule is bad, have: %+v\n", rq.Schedule)
+	}
+	if err := json.Unmarshal([]byte(queryResp), &rq); err != nil {
+		t.Fatal(err)
+	}
+	if rq.Schedule != nil {
+		t.Fatalf("Schedule is not nil, have: %+v\n", rq.Schedule)
+	}
+}
+
+func TestTyped(t *testing.T) {
+
+	jobId := "d856637d-9387-4874-a944-9c93ac45c688"
+
+	fq, err := Queries.PostFormatTyped("select 1 from dual;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "SELECT *\nFROM dual;"; fq.Query != want {
+		t.Fatalf("Query not matched,\n want: %q,\n have: %q\n", want, fq.Query)
+	}
+
+	rqs, err := Queries.GetSearchTyped("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rqs) != 1 || rqs[0].Name != "helloQuery" {
+		t.Fatalf("Search result is bad, have: %v\n", rqs)
+	}
+
+	rqs, err = Queries.GetRecentTyped()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rqs) != 1 {
+		t.Fatalf("Recent result is bad, have: %v\n", rqs)
+	}
+
+	prq, err := Queries.GetMyTyped(20, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prq.PageSize != 20 || prq.Page != 1 || len(prq.Results) != 1 {
+		t.Fatalf("Paging result is bad, have: %v\n", prq)
+	}
+
+	prq, err = Queries.GetQueryTyped(20, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prq.Count != 1 {
+		t.Fatalf("Count is bad,\n want: %d,\n have: %d\n", 1, prq.Count)
+	}
+
+	rq, err := Queries.PostQueryTyped(NewQuery{DataSourceId: 1, Query: "select * from hello;", Name: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rq.Name != "api" {
+		t.Fatalf("Name is not match,\n want: %q,\n have: %q\n", "api", rq.Name)
+	}
+
+	rq, err = Queries.PostQueryIdTyped(1, NewQuery{Name: "api2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rq.Name != "api2" {
+		t.Fatalf("Name is not match,\n want: %q,\n have: %q\n", "api2", rq.Name)
+	}
+
+	rq, err = Queries.PostForkTyped(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rq.Id != 2 {
+		t.Fatalf("Query id is not match,\n want: %d,\n have: %d\n", 2, rq.Id)
+	}
+
+	rq, err = Queries.GetQueryIdTyped(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rq.Id != 1 || rq.Name != "helloQuery" {
+		t.Fatalf("Query is bad, have: %v\n", rq)
+	}
+
+	if err := Queries.DeleteQueryTyped(1); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := Queries.PostRefreshTyped(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Job.Status != 2 {
+		t.Fatalf("Job status is not match,\n want: %d,\n have: %d\n", 2, job.Job.Status)
+	}
+
+	job, err = Queries.PostQueryResultTyped("select * from hello;", 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Job.Id != jobId {
+		t.Fatalf("Job id is not match,\n want: %q,\n have: %q\n", jobId, job.Job.Id)
+	}
+
+	job, err = Queries.GetJobTyped(jobId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Job.Status != 3 || job.Job.QueryResultId != 1 {
+		t.Fatalf("Job is bad, have: %v\n", job)
+	}
+
+	if err := Queries.DeleteJobTyped(jobId); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Queries.GetResultsByIdTyped(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.QueryResult.Id != 2 {
+		t.Fatalf("Query result id is not match,\n want: %d,\n have: %d\n", 2, result.QueryResult.Id)
+	}
+
+	result, err = Queries.GetResultsByQueryIdTyped(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.QueryResult.Data.Rows) != 2 {
+		t.Fatalf("Rows num is bad,\n want: %d,\n have: %d\n", 2, len(result.QueryResult.Data.Rows))
+	}
+
+	result, err = Queries.GetQueryResultsTyped(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.QueryResult.DataSourceId != 1 {
+		t.Fatalf("DataSourceId is not match,\n want: %d,\n have: %d\n", 1, result.QueryResult.DataSourceId)
+	}
+}
+
+func TestTagsAndFavorites(t *testing.T) {
+
+	taggedResp := `{"id": 1, "name": "helloQuery", "tags": ["sales", "kpi"], "is_favorite": true}`
+	pagingTaggedResp := `{"count": 1, "page": 1, "page_size": 20, "results": [` + taggedResp + `]}`
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"GET /api/queries":               {body: pagingTaggedResp},
+		"GET /api/queries/tags":          {body: `{"tags": [{"name": "sales", "count": 2}, {"name": "kpi", "count": 1}]}`},
+		"GET /api/queries/favorites":     {body: pagingTaggedResp},
+		"POST /api/queries/1/favorite":   {body: `null`},
+		"DELETE /api/queries/1/favorite": {body: `null`},
+	})
+	qs := QueriesS{client}
+
+	prq, err := qs.GetQueryByTags(20, 1, "sales", "kpi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rq := prq.Results[0]; !rq.IsFavorite || len(rq.Tags) != 2 || rq.Tags[1] != "kpi" {
+		t.Fatalf("Query is bad, have: %+v", rq)
+	}
+	if want := "page=1&page_size=20&tags=sales&tags=kpi"; bodies["GET /api/queries?"] != want {
+		t.Fatalf("Query is not match,\n want: %s,\n have: %s\n", want, bodies["GET /api/queries?"])
+	}
+
+	tags, err := qs.GetTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 || tags[0].Name != "sales" || tags[0].Count != 2 {
+		t.Fatalf("Tags is bad, have: %+v", tags)
+	}
+
+	if prq, err = qs.GetFavorites(20, 1); err != nil || prq.Count != 1 {
+		t.Fatalf("Favorites is bad, have: %+v, %v", prq, err)
+	}
+	if want := "page=1&page_size=20"; bodies["GET /api/queries/favorites?"] != want {
+		t.Fatalf("Query is not match,\n want: %s,\n have: %s\n", want, bodies["GET /api/queries/favorites?"])
+	}
+	if err = qs.PostFavorite(1); err != nil {
+		t.Fatal(err)
+	}
+	if err = qs.DeleteFavorite(1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryResultRequest(t *testing.T) {
+
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"POST /api/queries/format": {body: fromatResp},
+		"POST /api/query_results":  {body: queryResultResp},
+	})
+	qs := QueriesS{client}
+
+	sql := "select \"a\" from t\nwhere b = 'x\\y' and c < 1"
+	if _, err := qs.PostFormatTyped(sql); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"query":"select \"a\" from t\nwhere b = 'x\\y' and c < 1"}`; bodies["POST /api/queries/format"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/queries/format"])
+	}
+
+	if _, err := qs.PostQueryResult(sql, 60, 1); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"query":"select \"a\" from t\nwhere b = 'x\\y' and c < 1","data_source_id":1,"max_age":60}`; bodies["POST /api/query_results"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/query_results"])
+	}
+
+	req := QueryResultRequest{
+		Query:          "select {{ n }}",
+		DataSourceId:   1,
+		Parameters:     map[string]interface{}{"n": 1},
+		ApplyAutoLimit: true,
+		QueryId:        3,
+	}
+	jr, err := qs.PostQueryResultRequestTyped(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jr.QueryResult == nil || jr.Job != nil {
+		t.Fatalf("JobOrResult is bad, have: %+v", jr)
+	}
+	if want := `{"query":"select {{ n }}","data_source_id":1,"max_age":0,"parameters":{"n":1},"apply_auto_limit":true,"query_id":3}`; bodies["POST /api/query_results"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/query_results"])
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// Default QuerySnippets
+var QuerySnippets = &QuerySnippetsS{DefaultClient}
+
+// interface of make QuerySnippets endpoint.
+type QuerySnippetser interface {
+	QuerySnippets(string) string
+}
+
+// Default struct for query snippets.
+type QuerySnippetsS struct {
+	Client Interface
+}
+
+// Default implement of QuerySnippets.
+func (qss QuerySnippetsS) QuerySnippets(s string) (rs string) {
+	return "/api/query_snippets/" + s
+}
+
+// Wrap Redash query snippet.
+type QuerySnippet struct {
+	Id int `json:"id"`
+	// Trigger is the word to expand the snippet in the editor.
+	Trigger     string `json:"trigger"`
+	Description string `json:"description"`
+	Snippet     string `json:"snippet"`
+	User        *User  `json:"user,omitempty"`
+	UpdatedAt   string `json:"updated_at"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// Wrap Redash new query snippet. It is also used to update.
+type NewQuerySnippet struct {
+	Trigger     string `json:"trigger"`
+	Description string `json:"description"`
+	Snippet     string `json:"snippet"`
+}
+
+// Wrap Redash api GET query_snippets.
+func (qss QuerySnippetsS) GetQuerySnippets() (snippets []QuerySnippet, err error) {
+	return qss.GetQuerySnippetsContext(context.Background())
+}
+
+// GetQuerySnippetsContext is GetQuerySnippets with context.
+func (qss QuerySnippetsS) GetQuerySnippetsContext(ctx context.Context) (snippets []QuerySnippet, err error) {
+	if err = doJSON(ctx, qss.Client, http.MethodGet, qss.QuerySnippets(""), nil, nil, &snippets); err != nil {
+		return nil, err
+	}
+	return snippets, nil
+}
+
+// Wrap Redash api GET query_snippets/${snippet id}.
+func (qss QuerySnippetsS) GetQuerySnippet(snippetId int) (qs *QuerySnippet, err error) {
+	return qss.GetQuerySnippetContext(context.Background(), snippetId)
+}
+
+// GetQuerySnippetContext is GetQuerySnippet with context.
+func (qss QuerySnippetsS) GetQuerySnippetContext(ctx context.Context, snippetId int) (qs *QuerySnippet, err error) {
+	qs = &QuerySnippet{}
+	if err = doJSON(ctx, qss.Client, http.MethodGet, qss.QuerySnippets(strconv.Itoa(snippetId)), nil, nil, qs); err != nil {
+		return nil, err
+	}
+	return qs, nil
+}
+
+// Wrap Redash api POST query_snippets.
+func (qss QuerySnippetsS) PostQuerySnippet(newSnippet NewQuerySnippet) (qs *QuerySnippet, err error) {
+	return qss.PostQuerySnippetContext(context.Background(), newSnippet)
+}
+
+// PostQuerySnippetContext is PostQuerySnippet with context.
+func (qss QuerySnippetsS) PostQuerySnippetContext(ctx context.Context, newSnippet NewQuerySnippet) (qs *QuerySnippet, err error) {
+	qs = &QuerySnippet{}
+	if err = doJSON(ctx, qss.Client, http.MethodPost, qss.QuerySnippets(""), nil, newSnippet, qs); err != nil {
+		return nil, err
+	}
+	return qs, nil
+}
+
+// Wrap Redash api POST query_snippets/${snippet id}.
+func (qss QuerySnippetsS) PostQuerySnippetId(snippetId int, update NewQuerySnippet) (qs *QuerySnippet, err error) {
+	return qss.PostQuerySnippetIdContext(context.Background(), snippetId, update)
+}
+
+// PostQuerySnippetIdContext is PostQuerySnippetId with context.
+func (qss QuerySnippetsS) PostQuerySnippetIdContext(ctx context.Context, snippetId int, update NewQuerySnippet) (qs *QuerySnippet, err error) {
+	qs = &QuerySnippet{}
+	if err = doJSON(ctx, qss.Client, http.MethodPost, qss.QuerySnippets(strconv.Itoa(snippetId)), nil, update, qs); err != nil {
+		return nil, err
+	}
+	return qs, nil
+}
+
+// Wrap Redash api DELETE query_snippets/${snippet id}.
+func (qss QuerySnippetsS) DeleteQuerySnippet(snippetId int) (err error) {
+	return qss.DeleteQuerySnippetContext(context.Background(), snippetId)
+}
+
+// DeleteQuerySnippetContext is DeleteQuerySnippet with context.
+func (qss QuerySnippetsS) DeleteQuerySnippetContext(ctx context.Context, snippetId int) (err error) {
+	return doJSON(ctx, qss.Client, http.MethodDelete, qss.QuerySnippets(strconv.Itoa(snippetId)), nil, nil, nil)
+}
@@ -0,0 +1,59 @@
+package redash
+
+import (
+	"fmt"
+	"testing"
+)
+
+var querySnippetResp = fmt.Sprintf(`{
+  "id": 3,
+  "trigger": "active_users",
+  "description": "users active in 30 days",
+  "snippet": "select id from users where active_at > now() - interval '30 days'",
+  "user": %s,
+  "updated_at": "2017-07-16T10:52:26.541613+00:00",
+  "created_at": "2017-07-16T10:52:26.541613+00:00"
+}`, userResp)
+
+func TestQuerySnippets(t *testing.T) {
+
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"GET /api/query_snippets":      {body: "[" + querySnippetResp + "]"},
+		"GET /api/query_snippets/3":    {body: querySnippetResp},
+		"POST /api/query_snippets":     {body: querySnippetResp},
+		"POST /api/query_snippets/3":   {body: querySnippetResp},
+		"DELETE /api/query_snippets/3": {body: `null`},
+	})
+	qss := QuerySnippetsS{client}
+
+	snippets, err := qss.GetQuerySnippets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snippets) != 1 || snippets[0].Trigger != "active_users" || snippets[0].User == nil {
+		t.Fatalf("QuerySnippets is bad, have: %+v", snippets)
+	}
+
+	if _, err = qss.GetQuerySnippet(3); err != nil {
+		t.Fatal(err)
+	}
+
+	newSnippet := NewQuerySnippet{Trigger: "now", Snippet: "select now()"}
+	if _, err = qss.PostQuerySnippet(newSnippet); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"trigger":"now","description":"","snippet":"select now()"}`; bodies["POST /api/query_snippets"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/query_snippets"])
+	}
+	newSnippet.Description = "current time"
+	if _, err = qss.PostQuerySnippetId(3, newSnippet); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"trigger":"now","description":"current time","snippet":"select now()"}`; bodies["POST /api/query_snippets/3"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/query_snippets/3"])
+	}
+
+	if err = qss.DeleteQuerySnippet(3); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package redasharrow convert Redash query result into Apache Arrow
+// record batch, and write it as Parquet.
+//
+// Column types of result are kept in arrow schema:
+//
+//	integer  -> int64
+//	float    -> float64
+//	boolean  -> bool
+//	datetime -> timestamp(us, UTC)
+//	date     -> date32
+//	others   -> utf8
+//
+// All fields are nullable. It is separate module from redash, so that
+// redash does not depend on github.com/apache/arrow-go/v18. It needs
+// arrow-go v18.8.0 or later, and Go 1.25 or later.
+//
+//	$ go get github.com/ynishi/redash/redasharrow
+package redasharrow
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/ynishi/redash"
+)
+
+// timestampType is arrow type of datetime column.
+var timestampType = &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+
+// DataType return arrow type of Redash column type.
+func DataType(columnType string) arrow.DataType {
+	switch columnType {
+	case redash.TypeInteger:
+		return arrow.PrimitiveTypes.Int64
+	case redash.TypeFloat:
+		return arrow.PrimitiveTypes.Float64
+	case redash.TypeBoolean:
+		return arrow.FixedWidthTypes.Boolean
+	case redash.TypeDatetime:
+		return timestampType
+	case redash.TypeDate:
+		return arrow.FixedWidthTypes.Date32
+	}
+	return arrow.BinaryTypes.String
+}
+
+// Schema make arrow schema of result columns. Friendly name of column
+// is kept in field metadata "friendly_name".
+func Schema(columns []redash.Column) *arrow.Schema {
+	fields := make([]arrow.Field, len(columns))
+	for i, c := range columns {
+		fields[i] = arrow.Field{
+			Name:     c.Name,
+			Type:     DataType(c.Type),
+			Nullable: true,
+			Metadata: arrow.NewMetadata([]string{"friendly_name"}, []string{c.FriendlyName}),
+		}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// RecordBatch convert result into arrow record batch allocated by mem,
+// it must be released by caller. mem is memory.DefaultAllocator if nil.
+func RecordBatch(mem memory.Allocator, qr *redash.QueryResult) (rec arrow.RecordBatch, err error) {
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	columns := qr.Data.Columns
+	b := array.NewRecordBuilder(mem, Schema(columns))
+	defer b.Release()
+	for i, row := range qr.Data.Rows {
+		for j, c := range columns {
+			v, err := c.Value(row[c.Name])
+			if err == nil {
+				err = appendValue(b.Field(j), v)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("redasharrow: row %d column %q: %w", i, c.Name, err)
+			}
+		}
+	}
+	return b.NewRecordBatch(), nil
+}
+
+// appendValue append v converted by Column.Value to builder.
+func appendValue(fb array.Builder, v interface{}) error {
+	if v == nil {
+		fb.AppendNull()
+		return nil
+	}
+	switch b := fb.(type) {
+	case *array.Int64Builder:
+		if x, ok := v.(int64); ok {
+			b.Append(x)
+			return nil
+		}
+	case *array.Float64Builder:
+		if x, ok := v.(float64); ok {
+			b.Append(x)
+			return nil
+		}
+	case *array.BooleanBuilder:
+		if x, ok := v.(bool); ok {
+			b.Append(x)
+			return nil
+		}
+	case *array.TimestampBuilder:
+		if x, ok := v.(time.Time); ok {
+			b.Append(arrow.Timestamp(x.UnixMicro()))
+			return nil
+		}
+	case *array.Date32Builder:
+		if x, ok := v.(time.Time); ok {
+			b.Append(arrow.Date32FromTime(x))
+			return nil
+		}
+	case *array.StringBuilder:
+		b.Append(fmt.Sprint(v))
+		return nil
+	}
+	return fmt.Errorf("redasharrow: cannot append %v(%T) to %s", v, v, fb.Type())
+}
+
+// WriteParquet write result to w as parquet file with default
+// properties. w is closed if it is io.Closer, as parquet writer does.
+func WriteParquet(w io.Writer, qr *redash.QueryResult) (err error) {
+	return WriteParquetWith(w, qr, parquet.NewWriterProperties(), pqarrow.DefaultWriterProps())
+}
+
+// WriteParquetWith is WriteParquet with parquet and arrow writer
+// properties, like compression.
+func WriteParquetWith(w io.Writer, qr *redash.QueryResult, props *parquet.WriterProperties, arrprops pqarrow.ArrowWriterProperties) (err error) {
+	rec, err := RecordBatch(nil, qr)
+	if err != nil {
+		return err
+	}
+	defer rec.Release()
+	fw, err := pqarrow.NewFileWriter(rec.Schema(), w, props, arrprops)
+	if err != nil {
+		return err
+	}
+	if err = fw.Write(rec); err != nil {
+		fw.Close()
+		return err
+	}
+	return fw.Close()
+}
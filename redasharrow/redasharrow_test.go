@@ -0,0 +1,118 @@
+package redasharrow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/ynishi/redash"
+)
+
+const resultResp = `{
+  "id": 2,
+  "data": {
+    "columns": [
+      {"name": "id", "friendly_name": "ID", "type": "integer"},
+      {"name": "score", "friendly_name": "Score", "type": "float"},
+      {"name": "ok", "friendly_name": "OK", "type": "boolean"},
+      {"name": "name", "friendly_name": "Name", "type": "string"},
+      {"name": "at", "friendly_name": "At", "type": "datetime"},
+      {"name": "day", "friendly_name": "Day", "type": "date"}
+    ],
+    "rows": [
+      {"id": 1, "score": 1.5, "ok": true, "name": "a", "at": "2017-07-16T10:52:26+00:00", "day": "2017-07-16"},
+      {"id": 2, "score": null, "ok": false, "name": null, "at": null, "day": null}
+    ]
+  }
+}`
+
+func newResult(t *testing.T) *redash.QueryResult {
+	qr := &redash.QueryResult{}
+	if err := json.Unmarshal([]byte(resultResp), qr); err != nil {
+		t.Fatal(err)
+	}
+	return qr
+}
+
+func TestRecordBatch(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec, err := RecordBatch(mem, newResult(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	if rec.NumRows() != 2 || rec.NumCols() != 6 {
+		t.Fatalf("Shape is bad, have: %dx%d", rec.NumRows(), rec.NumCols())
+	}
+	want := []arrow.DataType{
+		arrow.PrimitiveTypes.Int64,
+		arrow.PrimitiveTypes.Float64,
+		arrow.FixedWidthTypes.Boolean,
+		arrow.BinaryTypes.String,
+		timestampType,
+		arrow.FixedWidthTypes.Date32,
+	}
+	for i, w := range want {
+		if f := rec.Schema().Field(i); !arrow.TypeEqual(f.Type, w) {
+			t.Errorf("Type of %s is not match, want: %s, have: %s", f.Name, w, f.Type)
+		}
+	}
+	if v, _ := rec.Schema().Field(0).Metadata.GetValue("friendly_name"); v != "ID" {
+		t.Fatalf("Metadata is bad, have: %s", v)
+	}
+	if v := rec.Column(0).(*array.Int64).Value(1); v != 2 {
+		t.Fatalf("Value is bad, have: %d", v)
+	}
+	at := time.Date(2017, 7, 16, 10, 52, 26, 0, time.UTC)
+	if v := rec.Column(4).(*array.Timestamp).Value(0); v != arrow.Timestamp(at.UnixMicro()) {
+		t.Fatalf("Timestamp is bad, have: %d", v)
+	}
+	for _, i := range []int{1, 3, 4, 5} {
+		if !rec.Column(i).IsNull(1) {
+			t.Errorf("Column %d of row 1 is not null", i)
+		}
+	}
+
+	qr := newResult(t)
+	qr.Data.Rows[0]["id"] = "x"
+	if _, err = RecordBatch(mem, qr); err == nil {
+		t.Fatal("Error is not returned for bad value")
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, newResult(t)); err != nil {
+		t.Fatal(err)
+	}
+	pf, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pf.Close()
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tbl, err := fr.ReadTable(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tbl.Release()
+	if tbl.NumRows() != 2 || tbl.NumCols() != 6 {
+		t.Fatalf("Shape is bad, have: %dx%d", tbl.NumRows(), tbl.NumCols())
+	}
+	if f := tbl.Schema().Field(5); !arrow.TypeEqual(f.Type, arrow.FixedWidthTypes.Date32) {
+		t.Fatalf("Type of day is bad, have: %s", f.Type)
+	}
+}
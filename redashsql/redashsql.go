@@ -0,0 +1,347 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package redashsql is database/sql driver backed by Redash.
+//
+// Query runs sql on a data source by Redash api POST query_results,
+// polls the job and return the result as rows. Transaction, Exec and
+// args are not supported, as Redash has no such api.
+//
+//	import _ "github.com/ynishi/redash/redashsql"
+//
+//	db, err := sql.Open("redash", "https://apikey@redash.example.com/?data_source=1")
+//	rows, err := db.QueryContext(ctx, "select id, name from users")
+//
+// DSN is url of Redash with api key as user, and params:
+//
+//	data_source  id or name of data source, required
+//	max_age      max age(seconds) of cached result, default 0
+//	timeout      max duration of a query like "30s", default no timeout
+package redashsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ynishi/redash"
+)
+
+// DriverName is name of the driver registered to database/sql.
+const DriverName = "redash"
+
+func init() {
+	sql.Register(DriverName, &Driver{})
+}
+
+// ErrNotSupported is returned for features Redash does not have.
+var ErrNotSupported = errors.New("redashsql: not supported")
+
+// Config is parsed DSN.
+type Config struct {
+	Url    string
+	Apikey string
+	// DataSource is id or name of data source.
+	DataSource string
+	MaxAge     int
+	Timeout    time.Duration
+}
+
+// ParseDSN parse dsn into Config.
+func ParseDSN(dsn string) (cfg *Config, err error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("redashsql: invalid dsn: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("redashsql: invalid dsn scheme %q", u.Scheme)
+	}
+	cfg = &Config{}
+	if u.User != nil {
+		cfg.Apikey = u.User.Username()
+	}
+	if cfg.Apikey == "" {
+		return nil, errors.New("redashsql: apikey is not in dsn")
+	}
+	q := u.Query()
+	if cfg.DataSource = q.Get("data_source"); cfg.DataSource == "" {
+		return nil, errors.New("redashsql: data_source is not in dsn")
+	}
+	if s := q.Get("max_age"); s != "" {
+		if cfg.MaxAge, err = strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("redashsql: invalid max_age: %w", err)
+		}
+	}
+	if s := q.Get("timeout"); s != "" {
+		if cfg.Timeout, err = time.ParseDuration(s); err != nil {
+			return nil, fmt.Errorf("redashsql: invalid timeout: %w", err)
+		}
+	}
+	u.User = nil
+	u.RawQuery = ""
+	cfg.Url = u.String()
+	return cfg, nil
+}
+
+// FormatDSN make dsn of cfg.
+func (cfg *Config) FormatDSN() string {
+	u, err := url.Parse(cfg.Url)
+	if err != nil {
+		u = &url.URL{}
+	}
+	u.User = url.User(cfg.Apikey)
+	q := url.Values{}
+	q.Set("data_source", cfg.DataSource)
+	if cfg.MaxAge != 0 {
+		q.Set("max_age", strconv.Itoa(cfg.MaxAge))
+	}
+	if cfg.Timeout != 0 {
+		q.Set("timeout", cfg.Timeout.String())
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// Driver is database/sql driver of Redash.
+type Driver struct{}
+
+// Open open new connection by dsn.
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	c, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return c.Connect(context.Background())
+}
+
+// OpenConnector make Connector by dsn.
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	cfg, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	client, err := redash.NewClient(cfg.Url, cfg.Apikey)
+	if err != nil {
+		return nil, err
+	}
+	opts := redash.DefaultExecuteOptions
+	opts.MaxAge = cfg.MaxAge
+	opts.Timeout = cfg.Timeout
+	return NewConnector(client, cfg.DataSource, &opts), nil
+}
+
+// Connector is driver.Connector with Redash client, use it with
+// sql.OpenDB to use own client.
+type Connector struct {
+	client     redash.Interface
+	dataSource string
+	opts       redash.ExecuteOptions
+
+	mu           sync.Mutex
+	dataSourceId int
+}
+
+// NewConnector make Connector of client. dataSource is id or name of
+// data source, opts is redash.DefaultExecuteOptions if nil.
+func NewConnector(client redash.Interface, dataSource string, opts *redash.ExecuteOptions) *Connector {
+	if opts == nil {
+		opts = &redash.DefaultExecuteOptions
+	}
+	return &Connector{client: client, dataSource: dataSource, opts: *opts}
+}
+
+// Connect return connection, data source name is resolved to id at
+// first connect.
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	id, err := c.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{client: c.client, dataSourceId: id, opts: c.opts}, nil
+}
+
+// Driver return Driver.
+func (c *Connector) Driver() driver.Driver {
+	return &Driver{}
+}
+
+// resolve get id of data source.
+func (c *Connector) resolve(ctx context.Context) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dataSourceId != 0 {
+		return c.dataSourceId, nil
+	}
+	if id, err := strconv.Atoi(c.dataSource); err == nil {
+		c.dataSourceId = id
+		return id, nil
+	}
+	ds, err := redash.DataSourcesS{Client: c.client}.GetDataSourceByNameContext(ctx, c.dataSource)
+	if err != nil {
+		return 0, err
+	}
+	c.dataSourceId = ds.Id
+	return ds.Id, nil
+}
+
+// conn is connection to a data source, it has no state on server.
+type conn struct {
+	client       redash.Interface
+	dataSourceId int
+	opts         redash.ExecuteOptions
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{c: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("%w: transaction", ErrNotSupported)
+}
+
+// Ping check data source is available. It finds data source in
+// GetDataSources, as GetDataSource is only for admin.
+func (c *conn) Ping(ctx context.Context) error {
+	dataSources, err := redash.DataSourcesS{Client: c.client}.GetDataSourcesContext(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ds := range dataSources {
+		if ds.Id == c.dataSourceId {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: data source %d", redash.ErrNotFound, c.dataSourceId)
+}
+
+// QueryContext execute query and wait for the result.
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if len(args) > 0 {
+		return nil, fmt.Errorf("%w: args", ErrNotSupported)
+	}
+	qr, err := redash.QueriesS{Client: c.client}.Execute(ctx, query, c.dataSourceId, &c.opts)
+	if err != nil {
+		return nil, err
+	}
+	return &rows{columns: qr.Data.Columns, data: qr.Data.Rows}, nil
+}
+
+// stmt is prepared query, which is just kept on client.
+type stmt struct {
+	c     *conn
+	query string
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+func (s *stmt) NumInput() int {
+	return 0
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("%w: exec", ErrNotSupported)
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	if len(args) > 0 {
+		return nil, fmt.Errorf("%w: args", ErrNotSupported)
+	}
+	return s.c.QueryContext(context.Background(), s.query, nil)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.c.QueryContext(ctx, s.query, args)
+}
+
+// rows is result of a query, all rows are already fetched.
+type rows struct {
+	columns []redash.Column
+	data    []redash.Row
+	i       int
+}
+
+func (r *rows) Columns() []string {
+	names := make([]string, len(r.columns))
+	for i, c := range r.columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func (r *rows) Close() error {
+	r.data = nil
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.i >= len(r.data) {
+		return io.EOF
+	}
+	row := r.data[r.i]
+	r.i++
+	for i, c := range r.columns {
+		v, err := c.Value(row[c.Name])
+		if err != nil {
+			return err
+		}
+		if dest[i], err = driverValue(v); err != nil {
+			return fmt.Errorf("redashsql: column %q: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// driverValue convert value of Column.Value to driver.Value, values of
+// unknown type like object are converted to json.
+func driverValue(v interface{}) (driver.Value, error) {
+	switch v.(type) {
+	case nil, int64, float64, bool, string, time.Time:
+		return v, nil
+	}
+	return json.Marshal(v)
+}
+
+// scanTypes is scan type by column type, all columns are nullable.
+var scanTypes = map[string]reflect.Type{
+	redash.TypeInteger:  reflect.TypeOf(sql.NullInt64{}),
+	redash.TypeFloat:    reflect.TypeOf(sql.NullFloat64{}),
+	redash.TypeBoolean:  reflect.TypeOf(sql.NullBool{}),
+	redash.TypeString:   reflect.TypeOf(sql.NullString{}),
+	redash.TypeDatetime: reflect.TypeOf(sql.NullTime{}),
+	redash.TypeDate:     reflect.TypeOf(sql.NullTime{}),
+}
+
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	if t, ok := scanTypes[r.columns[index].Type]; ok {
+		return t
+	}
+	return reflect.TypeOf(new(interface{})).Elem()
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return strings.ToUpper(r.columns[index].Type)
+}
+
+func (r *rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return true, true
+}
@@ -0,0 +1,173 @@
+package redashsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ynishi/redash"
+)
+
+const resultResp = `{
+  "query_result": {
+    "id": 2,
+    "data_source_id": 1,
+    "data": {
+      "columns": [
+        {"name": "id", "friendly_name": "id", "type": "integer"},
+        {"name": "name", "friendly_name": "name", "type": "string"},
+        {"name": "at", "friendly_name": "at", "type": "datetime"},
+        {"name": "extra", "friendly_name": "extra", "type": null}
+      ],
+      "rows": [
+        {"id": 1, "name": "a", "at": "2017-07-16T10:52:26+00:00", "extra": {"k": "v"}},
+        {"id": 2, "name": null, "at": null, "extra": null}
+      ]
+    }
+  }
+}`
+
+// newServer serve Redash api to run a query, and record query body.
+func newServer(t *testing.T) (ts *httptest.Server, bodies chan string) {
+	bodies = make(chan string, 10)
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Key abcdefg" {
+			http.Error(w, `{"message": "forbidden"}`, http.StatusForbidden)
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/data_sources":
+			fmt.Fprint(w, `[{"id": 1, "name": "pg", "type": "pg"}]`)
+		case "GET /api/data_sources/1":
+			// only admin can get data source with options.
+			http.Error(w, `{"message": "forbidden"}`, http.StatusForbidden)
+		case "POST /api/query_results":
+			buf, _ := io.ReadAll(r.Body)
+			bodies <- string(buf)
+			fmt.Fprint(w, `{"job": {"id": "x", "status": 3, "query_result_id": 2}}`)
+		case "GET /api/query_results/2":
+			fmt.Fprint(w, resultResp)
+		default:
+			http.Error(w, `{"message": "not found"}`, http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts, bodies
+}
+
+func TestParseDSN(t *testing.T) {
+	cfg, err := ParseDSN("https://abc@redash.example.com/redash?data_source=pg&max_age=60&timeout=30s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Url != "https://redash.example.com/redash" || cfg.Apikey != "abc" || cfg.DataSource != "pg" || cfg.MaxAge != 60 || cfg.Timeout != 30*time.Second {
+		t.Fatalf("Config is bad, have: %+v", cfg)
+	}
+	cfg2, err := ParseDSN(cfg.FormatDSN())
+	if err != nil || *cfg2 != *cfg {
+		t.Fatalf("FormatDSN is bad, have: %s, %v", cfg.FormatDSN(), err)
+	}
+
+	for _, dsn := range []string{
+		"postgres://abc@localhost/?data_source=1",
+		"https://localhost/?data_source=1",
+		"https://abc@localhost/",
+		"https://abc@localhost/?data_source=1&max_age=x",
+		"https://abc@localhost/?data_source=1&timeout=1",
+	} {
+		if _, err = ParseDSN(dsn); err == nil {
+			t.Errorf("Error is not returned for %s", dsn)
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	ts, bodies := newServer(t)
+	dsn := strings.Replace(ts.URL, "http://", "http://abcdefg@", 1) + "/?data_source=pg&max_age=10"
+	db, err := sql.Open(DriverName, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err = db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := db.QueryContext(context.Background(), "select * from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	if body := <-bodies; body != `{"query":"select * from t","data_source_id":1,"max_age":10}` {
+		t.Fatalf("Body is bad, have: %s", body)
+	}
+	cols, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cols[0].DatabaseTypeName() != "INTEGER" || cols[2].ScanType() != scanTypes["datetime"] {
+		t.Fatalf("ColumnTypes is bad, have: %v, %v", cols[0].DatabaseTypeName(), cols[2].ScanType())
+	}
+
+	var (
+		id    int64
+		name  sql.NullString
+		at    sql.NullTime
+		extra sql.NullString
+	)
+	var ids []int64
+	for rows.Next() {
+		if err = rows.Scan(&id, &name, &at, &extra); err != nil {
+			t.Fatal(err)
+		}
+		if id == 1 && (name.String != "a" || !at.Time.Equal(time.Date(2017, 7, 16, 10, 52, 26, 0, time.UTC)) || extra.String != `{"k":"v"}`) {
+			t.Fatalf("Row is bad, have: %v, %v, %v", name, at, extra)
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || name.Valid || at.Valid || extra.Valid {
+		t.Fatalf("Rows is bad, have: %v, %v", ids, name)
+	}
+
+	if _, err = db.Query("select ?", 1); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("Error is not ErrNotSupported, have: %v", err)
+	}
+	if _, err = db.Exec("delete from t"); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("Error is not ErrNotSupported, have: %v", err)
+	}
+	if _, err = db.Begin(); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("Error is not ErrNotSupported, have: %v", err)
+	}
+}
+
+func TestConnectError(t *testing.T) {
+	ts, _ := newServer(t)
+	dsn := strings.Replace(ts.URL, "http://", "http://abcdefg@", 1) + "/?data_source=nothing"
+	db, err := sql.Open(DriverName, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err = db.Ping(); err == nil {
+		t.Fatal("Error is not returned for unknown data source")
+	}
+
+	db2, err := sql.Open(DriverName, strings.Replace(dsn, "nothing", "2", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db2.Close()
+	if err = db2.Ping(); !errors.Is(err, redash.ErrNotFound) {
+		t.Fatalf("Error is not ErrNotFound, have: %v", err)
+	}
+}
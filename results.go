@@ -0,0 +1,278 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Redash column types, see Column.Type.
+const (
+	TypeInteger  = "integer"
+	TypeFloat    = "float"
+	TypeBoolean  = "boolean"
+	TypeString   = "string"
+	TypeDatetime = "datetime"
+	TypeDate     = "date"
+)
+
+// Errors of Record accessors.
+var (
+	ErrNoColumn  = errors.New("redash: no such column")
+	ErrNullValue = errors.New("redash: value is null")
+)
+
+// layouts to parse Redash datetime and date values.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Row is a row of result data keyed by column name.
+// Values are raw json values, numbers are kept as json.Number.
+type Row map[string]interface{}
+
+// UnmarshalJSON decode result data keeping numbers as json.Number,
+// so that large integers are not rounded to float64.
+func (rd *ResultData) UnmarshalJSON(b []byte) error {
+	type resultData ResultData
+	var raw resultData
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&raw); err != nil {
+		return err
+	}
+	*rd = ResultData(raw)
+	return nil
+}
+
+// Value convert raw json value v to Go value by column type,
+// integer to int64, float to float64, boolean to bool, string to string,
+// datetime and date to time.Time. null is converted to nil.
+// If type is unknown(or null), type is guessed from json value.
+func (c Column) Value(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch c.Type {
+	case TypeInteger:
+		switch x := v.(type) {
+		case json.Number:
+			if i, err := x.Int64(); err == nil {
+				return i, nil
+			}
+			// Some drivers return integer column as 1.0.
+			f, err := x.Float64()
+			if err == nil && f == float64(int64(f)) {
+				return int64(f), nil
+			}
+		case float64:
+			if x == float64(int64(x)) {
+				return int64(x), nil
+			}
+		case string:
+			if i, err := strconv.ParseInt(x, 10, 64); err == nil {
+				return i, nil
+			}
+		}
+	case TypeFloat:
+		switch x := v.(type) {
+		case json.Number:
+			if f, err := x.Float64(); err == nil {
+				return f, nil
+			}
+		case float64:
+			return x, nil
+		case string:
+			if f, err := strconv.ParseFloat(x, 64); err == nil {
+				return f, nil
+			}
+		}
+	case TypeBoolean:
+		switch x := v.(type) {
+		case bool:
+			return x, nil
+		case string:
+			if b, err := strconv.ParseBool(x); err == nil {
+				return b, nil
+			}
+		}
+	case TypeString:
+		switch x := v.(type) {
+		case string:
+			return x, nil
+		case json.Number:
+			return x.String(), nil
+		case bool:
+			return strconv.FormatBool(x), nil
+		}
+	case TypeDatetime, TypeDate:
+		if x, ok := v.(string); ok {
+			if t, err := parseTime(x); err == nil {
+				return t, nil
+			}
+		}
+	default:
+		if x, ok := v.(json.Number); ok {
+			if i, err := x.Int64(); err == nil {
+				return i, nil
+			}
+			return x.Float64()
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("redash: cannot convert %v(%T) to %s of column %q", v, v, c.Type, c.Name)
+}
+
+// parseTime parse s with Redash datetime layouts.
+func parseTime(s string) (t time.Time, err error) {
+	for _, layout := range timeLayouts {
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return t, err
+}
+
+// Record is a row of result data ordered by columns,
+// values are converted by Column.Value.
+type Record struct {
+	Columns []Column
+	Values  []interface{}
+}
+
+// Records convert Rows to Records ordered by Columns.
+func (rd ResultData) Records() (records []Record, err error) {
+	records = make([]Record, 0, len(rd.Rows))
+	for _, row := range rd.Rows {
+		values := make([]interface{}, len(rd.Columns))
+		for i, c := range rd.Columns {
+			if values[i], err = c.Value(row[c.Name]); err != nil {
+				return nil, err
+			}
+		}
+		records = append(records, Record{Columns: rd.Columns, Values: values})
+	}
+	return records, nil
+}
+
+// Index return index of column name, or -1 if not found.
+func (r Record) Index(name string) int {
+	for i, c := range r.Columns {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get return value of column name.
+func (r Record) Get(name string) (v interface{}, ok bool) {
+	i := r.Index(name)
+	if i < 0 {
+		return nil, false
+	}
+	return r.Values[i], true
+}
+
+// Map return values keyed by column name.
+func (r Record) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(r.Columns))
+	for i, c := range r.Columns {
+		m[c.Name] = r.Values[i]
+	}
+	return m
+}
+
+// value return non null value of column name.
+func (r Record) value(name string) (interface{}, error) {
+	v, ok := r.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNoColumn, name)
+	}
+	if v == nil {
+		return nil, fmt.Errorf("%w: %q", ErrNullValue, name)
+	}
+	return v, nil
+}
+
+func typeError(name string, v interface{}, want string) error {
+	return fmt.Errorf("redash: value of column %q is %T, not %s", name, v, want)
+}
+
+// Int64 return value of integer column.
+func (r Record) Int64(name string) (int64, error) {
+	v, err := r.value(name)
+	if err != nil {
+		return 0, err
+	}
+	i, ok := v.(int64)
+	if !ok {
+		return 0, typeError(name, v, "int64")
+	}
+	return i, nil
+}
+
+// Float64 return value of float(or integer) column.
+func (r Record) Float64(name string) (float64, error) {
+	v, err := r.value(name)
+	if err != nil {
+		return 0, err
+	}
+	switch x := v.(type) {
+	case float64:
+		return x, nil
+	case int64:
+		return float64(x), nil
+	}
+	return 0, typeError(name, v, "float64")
+}
+
+// Bool return value of boolean column.
+func (r Record) Bool(name string) (bool, error) {
+	v, err := r.value(name)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, typeError(name, v, "bool")
+	}
+	return b, nil
+}
+
+// String return value of string column.
+func (r Record) String(name string) (string, error) {
+	v, err := r.value(name)
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", typeError(name, v, "string")
+	}
+	return s, nil
+}
+
+// Time return value of datetime or date column.
+func (r Record) Time(name string) (time.Time, error) {
+	v, err := r.value(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, ok := v.(time.Time)
+	if !ok {
+		return time.Time{}, typeError(name, v, "time.Time")
+	}
+	return t, nil
+}
@@ -0,0 +1,122 @@
+package redash
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+const typedResultResp = `{
+  "query_result": {
+    "id": 3,
+    "data": {
+      "rows": [
+        {
+          "id": 9007199254740993,
+          "price": 1.5,
+          "active": true,
+          "name": "test1",
+          "created_at": "2017-07-16T11:49:35.033971+00:00",
+          "day": "2017-07-16",
+          "memo": null
+        }
+      ],
+      "columns": [
+        {"friendly_name": "id", "type": "integer", "name": "id"},
+        {"friendly_name": "price", "type": "float", "name": "price"},
+        {"friendly_name": "active", "type": "boolean", "name": "active"},
+        {"friendly_name": "name", "type": "string", "name": "name"},
+        {"friendly_name": "created_at", "type": "datetime", "name": "created_at"},
+        {"friendly_name": "day", "type": "date", "name": "day"},
+        {"friendly_name": "memo", "type": null, "name": "memo"}
+      ]
+    }
+  }
+}`
+
+func TestRecords(t *testing.T) {
+
+	var result Result
+	if err := json.Unmarshal([]byte(typedResultResp), &result); err != nil {
+		t.Fatal(err)
+	}
+	data := result.QueryResult.Data
+	if have := data.Rows[0]["name"]; have != "test1" {
+		t.Fatalf("Row value is not match,\n want: %q,\n have: %v\n", "test1", have)
+	}
+	records, err := data.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Records num is bad,\n want: %d,\n have: %d\n", 1, len(records))
+	}
+	r := records[0]
+	if len(r.Values) != len(data.Columns) || r.Index("name") != 3 {
+		t.Fatalf("Record is not ordered by columns, have: %v", r)
+	}
+
+	if id, err := r.Int64("id"); err != nil || id != 9007199254740993 {
+		t.Fatalf("Int64 is bad, have: %d, %v", id, err)
+	}
+	if price, err := r.Float64("price"); err != nil || price != 1.5 {
+		t.Fatalf("Float64 is bad, have: %f, %v", price, err)
+	}
+	if active, err := r.Bool("active"); err != nil || !active {
+		t.Fatalf("Bool is bad, have: %v, %v", active, err)
+	}
+	if name, err := r.String("name"); err != nil || name != "test1" {
+		t.Fatalf("String is bad, have: %q, %v", name, err)
+	}
+	want := time.Date(2017, 7, 16, 11, 49, 35, 33971000, time.UTC)
+	if createdAt, err := r.Time("created_at"); err != nil || !createdAt.Equal(want) {
+		t.Fatalf("Time is bad, have: %v, %v", createdAt, err)
+	}
+	if day, err := r.Time("day"); err != nil || day.Day() != 16 {
+		t.Fatalf("Time of date is bad, have: %v, %v", day, err)
+	}
+	if _, err := r.String("memo"); !errors.Is(err, ErrNullValue) {
+		t.Fatalf("Null value error is bad, have: %v", err)
+	}
+	if _, err := r.String("nothing"); !errors.Is(err, ErrNoColumn) {
+		t.Fatalf("No column error is bad, have: %v", err)
+	}
+	if _, err := r.Int64("name"); err == nil {
+		t.Fatal("Type mismatch should be error")
+	}
+	if m := r.Map(); m["active"] != true {
+		t.Fatalf("Map is bad, have: %v", m)
+	}
+}
+
+func TestColumnValue(t *testing.T) {
+
+	cases := []struct {
+		typ  string
+		in   interface{}
+		want interface{}
+	}{
+		{TypeInteger, json.Number("1.0"), int64(1)},
+		{TypeInteger, "12", int64(12)},
+		{TypeFloat, json.Number("2"), float64(2)},
+		{TypeBoolean, "true", true},
+		{TypeString, json.Number("3"), "3"},
+		{"", json.Number("4"), int64(4)},
+		{"", json.Number("4.5"), 4.5},
+		{"", "abc", "abc"},
+		{TypeDate, nil, nil},
+	}
+	for _, c := range cases {
+		have, err := Column{Name: "c", Type: c.typ}.Value(c.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != c.want {
+			t.Fatalf("Value of %s is bad,\n want: %v(%T),\n have: %v(%T)\n", c.typ, c.want, c.want, have, have)
+		}
+	}
+	if _, err := (Column{Name: "c", Type: TypeInteger}).Value(json.Number("1.5")); err == nil {
+		t.Fatal("1.5 should not be converted to integer")
+	}
+}
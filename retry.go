@@ -0,0 +1,174 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy is policy to retry request on transient failures.
+//
+// Idempotent methods(GET, HEAD, OPTIONS, PUT, DELETE) and requests
+// with Options.Idempotent are retried on network error and on
+// StatusCodes. Other requests like POST of PostQuery are retried only
+// on 429 Too Many Requests, as the server did not process them.
+type RetryPolicy struct {
+	// MaxAttempts is max number of attempts including first one.
+	MaxAttempts int
+	// Backoff is wait duration setting between attempts.
+	Backoff Backoff
+	// Jitter is ratio(0 to 1) to randomize wait duration.
+	Jitter float64
+	// StatusCodes is retryable status, nil means DefaultRetryStatusCodes.
+	StatusCodes []int
+}
+
+// DefaultRetryStatusCodes is used when RetryPolicy.StatusCodes is nil.
+var DefaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// DefaultRetryPolicy is retry policy of DefaultClient.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff: Backoff{
+		Initial:    500 * time.Millisecond,
+		Max:        10 * time.Second,
+		Multiplier: 2,
+	},
+	Jitter: 0.2,
+}
+
+// RetryPolicyer is optional interface for client of Interface.
+// If client implements it, DoInter retries requests by the policy.
+// Returning nil means no retry.
+type RetryPolicyer interface {
+	RetryPolicy() *RetryPolicy
+}
+
+// retryable return whether request should be retried.
+func (p *RetryPolicy) retryable(method string, opts *Options, resp *http.Response, err error) bool {
+	idempotent := opts.Idempotent
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		idempotent = true
+	}
+	if err != nil {
+		return idempotent
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if !idempotent {
+		return false
+	}
+	codes := p.StatusCodes
+	if codes == nil {
+		codes = DefaultRetryStatusCodes
+	}
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// wait return duration before next attempt, Retry-After header of
+// resp is used if exists.
+func (p *RetryPolicy) wait(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+	d := p.Backoff.Duration(attempt)
+	if p.Jitter > 0 {
+		d = time.Duration(float64(d) * (1 + p.Jitter*(rand.Float64()*2-1)))
+	}
+	return d
+}
+
+// retryAfter parse Retry-After header, which is seconds or http date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if sec, err := strconv.Atoi(v); err == nil && sec >= 0 {
+		return time.Duration(sec) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// doRetry do request by policy, and return last response or error.
+func doRetry(ctx context.Context, client Interface, logger Logger, policy *RetryPolicy, method, sub string, opts *Options) (resp *http.Response, err error) {
+	var body []byte
+	if opts.Body != nil {
+		if body, err = ioutil.ReadAll(opts.Body); err != nil {
+			return nil, err
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		if opts.Body != nil {
+			opts.Body = bytes.NewReader(body)
+		}
+		resp, err = doOnce(ctx, client, method, sub, opts)
+		if attempt+1 >= policy.MaxAttempts || ctx.Err() != nil || !policy.retryable(method, opts, resp, err) {
+			return resp, err
+		}
+		wait := policy.wait(attempt, resp)
+		if resp != nil {
+			logger.Log(ctx, LevelWarn, "redash: retry request", "method", method, "path", sub,
+				"attempt", attempt+1, "wait", wait, "status", resp.StatusCode)
+		} else {
+			logger.Log(ctx, LevelWarn, "redash: retry request", "method", method, "path", sub,
+				"attempt", attempt+1, "wait", wait, "error", err)
+		}
+		timer := time.NewTimer(wait)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// doOnce make request and do it once.
+func doOnce(ctx context.Context, client Interface, method, sub string, opts *Options) (resp *http.Response, err error) {
+	req, err := RequestInterContext(ctx, client, method, sub, opts)
+	if err != nil {
+		return nil, err
+	}
+	hc := client.HTTPClient()
+	if hc.Timeout > 0 && isStream(ctx) {
+		// copy not to change the given client.
+		c := *hc
+		c.Timeout = 0
+		hc = &c
+	}
+	return hc.Do(req)
+}
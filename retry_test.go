@@ -0,0 +1,131 @@
+package redash
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type retryClientData struct {
+	mockClientData
+	policy *RetryPolicy
+}
+
+func (rc retryClientData) RetryPolicy() *RetryPolicy {
+	return rc.policy
+}
+
+var fastRetryPolicy = &RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     Backoff{Initial: time.Millisecond, Multiplier: 2},
+}
+
+// newFlakyServer make mock server which responds status for first
+// fails requests and then responds ok with request body.
+func newFlakyServer(fails int32, status int, called *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, _ := ioutil.ReadAll(r.Body)
+		if atomic.AddInt32(called, 1) <= fails {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "temporary", status)
+			return
+		}
+		fmt.Fprintf(w, `{"body": %q}`, buf)
+	}))
+}
+
+func TestRetry(t *testing.T) {
+
+	cases := []struct {
+		name   string
+		method string
+		status int
+		fails  int32
+		called int32
+		ok     bool
+	}{
+		{"GET retried", http.MethodGet, http.StatusServiceUnavailable, 2, 3, true},
+		{"GET exhausted", http.MethodGet, http.StatusBadGateway, 3, 3, false},
+		{"GET not retryable", http.MethodGet, http.StatusInternalServerError, 1, 1, false},
+		{"POST not retried", http.MethodPost, http.StatusServiceUnavailable, 1, 1, false},
+		{"POST rate limited", http.MethodPost, http.StatusTooManyRequests, 1, 2, true},
+	}
+	for _, c := range cases {
+		var called int32
+		ts := newFlakyServer(c.fails, c.status, &called)
+		client := retryClientData{mockClientData{MockUrl: ts.URL}, fastRetryPolicy}
+
+		var resp *http.Response
+		var err error
+		if c.method == http.MethodPost {
+			resp, err = PostInter(client, "api/retry", []byte(`{"a":1}`))
+		} else {
+			resp, err = GetInter(client, "api/retry", nil)
+		}
+		ts.Close()
+
+		if called != c.called {
+			t.Fatalf("%s: called is not match,\n want: %d,\n have: %d\n", c.name, c.called, called)
+		}
+		if !c.ok {
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != c.status {
+				t.Fatalf("%s: error is bad, have: %v", c.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		buf, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if c.method == http.MethodPost && string(buf) != `{"body": "{\"a\":1}"}` {
+			t.Fatalf("%s: body is not resent, have: %s", c.name, buf)
+		}
+	}
+}
+
+func TestRetryIdempotent(t *testing.T) {
+
+	var called int32
+	ts := newFlakyServer(1, http.StatusServiceUnavailable, &called)
+	defer ts.Close()
+	client := retryClientData{mockClientData{MockUrl: ts.URL}, fastRetryPolicy}
+
+	opts := client.DefaultOpts()
+	opts.Idempotent = true
+	resp, err := DoInter(client, http.MethodPost, "api/retry", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if called != 2 {
+		t.Fatalf("Idempotent POST should be retried, called: %d", called)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+
+	if d, ok := retryAfter("3"); !ok || d != 3*time.Second {
+		t.Fatalf("Seconds is bad, have: %v %v", d, ok)
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if d, ok := retryAfter(date); !ok || d < 59*time.Minute {
+		t.Fatalf("Date is bad, have: %v %v", d, ok)
+	}
+	if _, ok := retryAfter("soon"); ok {
+		t.Fatal("Invalid value should be ignored")
+	}
+
+	p := &RetryPolicy{Backoff: Backoff{Initial: time.Second, Multiplier: 2}, Jitter: 0.5}
+	for i := 0; i < 10; i++ {
+		if d := p.wait(1, nil); d < time.Second || d > 3*time.Second {
+			t.Fatalf("Jittered wait is out of range, have: %v", d)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// ScanError is returned when ScanInto failed to set column to field.
+type ScanError struct {
+	// Row is index of row, -1 if error is not for a row.
+	Row    int
+	Column string
+	Field  string
+	Err    error
+}
+
+func (e *ScanError) Error() string {
+	if e.Row < 0 {
+		return fmt.Sprintf("redash: scan column %q into field %s: %v", e.Column, e.Field, e.Err)
+	}
+	return fmt.Sprintf("redash: scan row %d column %q into field %s: %v", e.Row, e.Column, e.Field, e.Err)
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// scanField is a struct field mapped to column.
+type scanField struct {
+	index  []int
+	name   string
+	column int
+}
+
+// ScanInto set rows of result into dest, which is pointer to slice of
+// struct(or pointer to struct). Columns are mapped to fields by tag
+// like `redash:"col"`, fields without tag are mapped by name ignoring
+// case, and tag "-" skips the field. Tag option "optional" like
+// `redash:"col,optional"` allows the column not to be in result.
+// Values are converted by Column.Value, then set to field, so type
+// mismatch and missing columns are reported as ScanError.
+// null can be set only to pointer or interface field.
+func (qr QueryResult) ScanInto(dest interface{}) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("redash: ScanInto needs pointer to slice, not %T", dest)
+	}
+	sv := dv.Elem()
+	et := sv.Type().Elem()
+	isPtr := et.Kind() == reflect.Ptr
+	st := et
+	if isPtr {
+		st = et.Elem()
+	}
+	if st.Kind() != reflect.Struct {
+		return fmt.Errorf("redash: ScanInto needs slice of struct, not %s", sv.Type())
+	}
+	fields, err := scanFields(st, qr.Data.Columns)
+	if err != nil {
+		return err
+	}
+	records, err := qr.Data.Records()
+	if err != nil {
+		return err
+	}
+	out := reflect.MakeSlice(sv.Type(), 0, len(records))
+	for i, r := range records {
+		ev := reflect.New(st).Elem()
+		for _, f := range fields {
+			c := qr.Data.Columns[f.column]
+			if err := setField(ev.FieldByIndex(f.index), r.Values[f.column]); err != nil {
+				return &ScanError{Row: i, Column: c.Name, Field: f.name, Err: err}
+			}
+		}
+		if isPtr {
+			ev = ev.Addr()
+		}
+		out = reflect.Append(out, ev)
+	}
+	sv.Set(out)
+	return nil
+}
+
+// scanFields map exported fields of st to columns.
+func scanFields(st reflect.Type, columns []Column) (fields []scanField, err error) {
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		tag := sf.Tag.Get("redash")
+		if tag == "-" {
+			continue
+		}
+		name, opt := tag, ""
+		if j := strings.Index(tag, ","); j >= 0 {
+			name, opt = tag[:j], tag[j+1:]
+		}
+		column := -1
+		for j, c := range columns {
+			if (name != "" && c.Name == name) || (name == "" && strings.EqualFold(c.Name, sf.Name)) {
+				column = j
+				break
+			}
+		}
+		if column < 0 {
+			if name != "" && opt != "optional" {
+				return nil, &ScanError{Row: -1, Column: name, Field: sf.Name, Err: ErrNoColumn}
+			}
+			continue
+		}
+		fields = append(fields, scanField{index: sf.Index, name: sf.Name, column: column})
+	}
+	return fields, nil
+}
+
+// setField set converted value v to field fv.
+func setField(fv reflect.Value, v interface{}) error {
+	if v == nil {
+		switch fv.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			fv.Set(reflect.Zero(fv.Type()))
+			return nil
+		}
+		return ErrNullValue
+	}
+	if fv.Kind() == reflect.Ptr {
+		pv := reflect.New(fv.Type().Elem())
+		if err := setField(pv.Elem(), v); err != nil {
+			return err
+		}
+		fv.Set(pv)
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, ok := v.(int64); ok && fv.Type() != reflect.TypeOf(time.Duration(0)) {
+			if fv.OverflowInt(i) {
+				return fmt.Errorf("value %d overflows %s", i, fv.Type())
+			}
+			fv.SetInt(i)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if i, ok := v.(int64); ok {
+			if i < 0 || fv.OverflowUint(uint64(i)) {
+				return fmt.Errorf("value %d overflows %s", i, fv.Type())
+			}
+			fv.SetUint(uint64(i))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch x := v.(type) {
+		case float64:
+			fv.SetFloat(x)
+			return nil
+		case int64:
+			fv.SetFloat(float64(x))
+			return nil
+		}
+	case reflect.Interface:
+		if rv.Type().AssignableTo(fv.Type()) {
+			fv.Set(rv)
+			return nil
+		}
+	default:
+		if rv.Type().ConvertibleTo(fv.Type()) && (fv.Kind() == rv.Kind() || fv.Type() == timeType) {
+			fv.Set(rv.Convert(fv.Type()))
+			return nil
+		}
+	}
+	return errors.New("cannot set " + rv.Type().String() + " to " + fv.Type().String())
+}
@@ -0,0 +1,95 @@
+package redash
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+type scanItem struct {
+	Id        int64     `redash:"id"`
+	Price     float32   `redash:"price"`
+	Active    bool      `redash:"active"`
+	Name      string    // mapped by name
+	CreatedAt time.Time `redash:"created_at"`
+	Memo      *string   `redash:"memo"`
+	Extra     string    `redash:"extra,optional"`
+	Ignored   string    `redash:"-"`
+}
+
+func typedQueryResult(t *testing.T) QueryResult {
+	var result Result
+	if err := json.Unmarshal([]byte(typedResultResp), &result); err != nil {
+		t.Fatal(err)
+	}
+	return result.QueryResult
+}
+
+func TestScanInto(t *testing.T) {
+
+	qr := typedQueryResult(t)
+
+	var items []scanItem
+	if err := qr.ScanInto(&items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("Items num is bad,\n want: %d,\n have: %d\n", 1, len(items))
+	}
+	item := items[0]
+	if item.Id != 9007199254740993 || item.Price != 1.5 || !item.Active || item.Name != "test1" {
+		t.Fatalf("Item is bad, have: %+v", item)
+	}
+	if item.CreatedAt.Year() != 2017 || item.Memo != nil {
+		t.Fatalf("Item is bad, have: %+v", item)
+	}
+
+	var ptrs []*scanItem
+	if err := qr.ScanInto(&ptrs); err != nil {
+		t.Fatal(err)
+	}
+	if len(ptrs) != 1 || ptrs[0].Name != "test1" {
+		t.Fatalf("Items is bad, have: %+v", ptrs)
+	}
+}
+
+func TestScanIntoError(t *testing.T) {
+
+	qr := typedQueryResult(t)
+
+	var missing []struct {
+		Nothing string `redash:"nothing"`
+	}
+	err := qr.ScanInto(&missing)
+	var scanErr *ScanError
+	if !errors.As(err, &scanErr) || !errors.Is(err, ErrNoColumn) || scanErr.Column != "nothing" {
+		t.Fatalf("Missing column error is bad, have: %v", err)
+	}
+
+	var mismatched []struct {
+		Name int `redash:"name"`
+	}
+	err = qr.ScanInto(&mismatched)
+	if !errors.As(err, &scanErr) || scanErr.Row != 0 || scanErr.Field != "Name" {
+		t.Fatalf("Mismatched type error is bad, have: %v", err)
+	}
+
+	var null []struct {
+		Memo string `redash:"memo"`
+	}
+	if err = qr.ScanInto(&null); !errors.Is(err, ErrNullValue) {
+		t.Fatalf("Null error is bad, have: %v", err)
+	}
+
+	var overflow []struct {
+		Id int32 `redash:"id"`
+	}
+	if err = qr.ScanInto(&overflow); err == nil {
+		t.Fatal("Overflow should be error")
+	}
+
+	if err = qr.ScanInto([]scanItem{}); err == nil {
+		t.Fatal("Not pointer should be error")
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Default Users
+var Users = &UsersS{DefaultClient}
+
+// interface of make Users endpoint.
+type Userser interface {
+	Users(string) string
+}
+
+// Default struct for users.
+type UsersS struct {
+	Client Interface
+}
+
+// Default implement of Users.
+func (us UsersS) Users(s string) (rs string) {
+	return "/api/users/" + s
+}
+
+// Wrap Redash group of user.
+type UserGroup struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// UnmarshalJSON accept group as id(single user) or object(list).
+func (ug *UserGroup) UnmarshalJSON(b []byte) error {
+	var id int
+	if err := json.Unmarshal(b, &id); err == nil {
+		*ug = UserGroup{Id: id}
+		return nil
+	}
+	type userGroup UserGroup
+	var g userGroup
+	if err := json.Unmarshal(b, &g); err != nil {
+		return err
+	}
+	*ug = UserGroup(g)
+	return nil
+}
+
+// Wrap Redash user.
+type User struct {
+	Id                  int         `json:"id"`
+	Name                string      `json:"name"`
+	Email               string      `json:"email"`
+	ProfileImageUrl     string      `json:"profile_image_url"`
+	Groups              []UserGroup `json:"groups"`
+	AuthType            string      `json:"auth_type"`
+	IsDisabled          bool        `json:"is_disabled"`
+	IsInvitationPending bool        `json:"is_invitation_pending"`
+	// ApiKey is only in response of the user or admin.
+	ApiKey     string `json:"api_key"`
+	ActiveAt   string `json:"active_at"`
+	DisabledAt string `json:"disabled_at"`
+	UpdatedAt  string `json:"updated_at"`
+	CreatedAt  string `json:"created_at"`
+	// InviteLink is only in response of PostUser.
+	InviteLink string `json:"invite_link"`
+}
+
+// Wrap Redash paging response user.
+type PagingResponseUser struct {
+	Count    int    `json:"count"`
+	Page     int    `json:"page"`
+	PageSize int    `json:"page_size"`
+	Results  []User `json:"results"`
+}
+
+// Wrap Redash new user to invite.
+type NewUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Wrap Redash user update, zero fields are not updated.
+type UserUpdate struct {
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	GroupIds []int  `json:"group_ids,omitempty"`
+}
+
+// Wrap Redash api GET users. q searches name and email, empty means all.
+func (us UsersS) GetUsers(pageSize, page int, q string) (pru *PagingResponseUser, err error) {
+	return us.GetUsersContext(context.Background(), pageSize, page, q)
+}
+
+// GetUsersContext is GetUsers with context.
+func (us UsersS) GetUsersContext(ctx context.Context, pageSize, page int, q string) (pru *PagingResponseUser, err error) {
+	params := map[string]string{"page_size": strconv.Itoa(pageSize), "page": strconv.Itoa(page)}
+	if q != "" {
+		params["q"] = q
+	}
+	pru = &PagingResponseUser{}
+	if err = doJSON(ctx, us.Client, http.MethodGet, us.Users(""), params, nil, pru); err != nil {
+		return nil, err
+	}
+	return pru, nil
+}
+
+// Wrap Redash api GET users/${user id}.
+func (us UsersS) GetUser(userId int) (u *User, err error) {
+	return us.GetUserContext(context.Background(), userId)
+}
+
+// GetUserContext is GetUser with context.
+func (us UsersS) GetUserContext(ctx context.Context, userId int) (u *User, err error) {
+	u = &User{}
+	if err = doJSON(ctx, us.Client, http.MethodGet, us.Users(strconv.Itoa(userId)), nil, nil, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Wrap Redash api POST users, which invites new user.
+func (us UsersS) PostUser(newUser NewUser) (u *User, err error) {
+	return us.PostUserContext(context.Background(), newUser)
+}
+
+// PostUserContext is PostUser with context.
+func (us UsersS) PostUserContext(ctx context.Context, newUser NewUser) (u *User, err error) {
+	u = &User{}
+	if err = doJSON(ctx, us.Client, http.MethodPost, us.Users(""), nil, newUser, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Wrap Redash api POST users/${user id}.
+func (us UsersS) PostUserId(userId int, update UserUpdate) (u *User, err error) {
+	return us.PostUserIdContext(context.Background(), userId, update)
+}
+
+// PostUserIdContext is PostUserId with context.
+func (us UsersS) PostUserIdContext(ctx context.Context, userId int, update UserUpdate) (u *User, err error) {
+	u = &User{}
+	if err = doJSON(ctx, us.Client, http.MethodPost, us.Users(strconv.Itoa(userId)), nil, update, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Wrap Redash api POST disable, which disables user.
+func (us UsersS) PostDisable(userId int) (u *User, err error) {
+	return us.PostDisableContext(context.Background(), userId)
+}
+
+// PostDisableContext is PostDisable with context.
+func (us UsersS) PostDisableContext(ctx context.Context, userId int) (u *User, err error) {
+	u = &User{}
+	if err = doJSON(ctx, us.Client, http.MethodPost, us.Users(fmt.Sprintf("%d/disable", userId)), nil, nil, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Wrap Redash api DELETE disable, which enables user.
+func (us UsersS) DeleteDisable(userId int) (u *User, err error) {
+	return us.DeleteDisableContext(context.Background(), userId)
+}
+
+// DeleteDisableContext is DeleteDisable with context.
+func (us UsersS) DeleteDisableContext(ctx context.Context, userId int) (u *User, err error) {
+	u = &User{}
+	if err = doJSON(ctx, us.Client, http.MethodDelete, us.Users(fmt.Sprintf("%d/disable", userId)), nil, nil, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Wrap Redash api POST regenerate_api_key, the user has new ApiKey.
+func (us UsersS) PostRegenerateApiKey(userId int) (u *User, err error) {
+	return us.PostRegenerateApiKeyContext(context.Background(), userId)
+}
+
+// PostRegenerateApiKeyContext is PostRegenerateApiKey with context.
+func (us UsersS) PostRegenerateApiKeyContext(ctx context.Context, userId int) (u *User, err error) {
+	u = &User{}
+	if err = doJSON(ctx, us.Client, http.MethodPost, us.Users(fmt.Sprintf("%d/regenerate_api_key", userId)), nil, nil, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
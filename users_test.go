@@ -0,0 +1,89 @@
+package redash
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+const userResp = `{
+  "id": 2,
+  "name": "user2",
+  "email": "user2@example.com",
+  "profile_image_url": "",
+  "groups": [1, 2],
+  "auth_type": "password",
+  "is_disabled": false,
+  "is_invitation_pending": true,
+  "api_key": "abcdef",
+  "updated_at": "2017-07-16T10:15:31.897134+00:00",
+  "created_at": "2017-07-16T10:15:31.897134+00:00"
+}`
+
+const listedUserResp = `{
+  "id": 2,
+  "name": "user2",
+  "email": "user2@example.com",
+  "groups": [{"id": 1, "name": "admin"}, {"id": 2, "name": "default"}]
+}`
+
+var pagingUserResp = fmt.Sprintf(`{
+  "count": 1,
+  "page": 1,
+  "page_size": 20,
+  "results": [%s]
+}`, listedUserResp)
+
+func TestUsers(t *testing.T) {
+
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"GET /api/users":                       {body: pagingUserResp},
+		"POST /api/users":                      {body: userResp},
+		"GET /api/users/2":                     {body: userResp},
+		"POST /api/users/2":                    {body: userResp},
+		"POST /api/users/2/disable":            {body: `{"id": 2, "is_disabled": true}`},
+		"DELETE /api/users/2/disable":          {body: `{"id": 2, "is_disabled": false}`},
+		"POST /api/users/2/regenerate_api_key": {body: `{"id": 2, "api_key": "newkey"}`},
+	})
+	us := UsersS{client}
+
+	pru, err := us.GetUsers(20, 1, "user2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []UserGroup{{1, "admin"}, {2, "default"}}
+	if pru.Count != 1 || !reflect.DeepEqual(pru.Results[0].Groups, want) {
+		t.Fatalf("Users is bad, have: %+v", pru)
+	}
+
+	u, err := us.GetUser(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []UserGroup{{Id: 1}, {Id: 2}}; !reflect.DeepEqual(u.Groups, want) || !u.IsInvitationPending {
+		t.Fatalf("User is bad, have: %+v", u)
+	}
+
+	if _, err = us.PostUser(NewUser{Name: "user2", Email: "user2@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"user2","email":"user2@example.com"}`; bodies["POST /api/users"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/users"])
+	}
+	if _, err = us.PostUserId(2, UserUpdate{Name: "user3"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"user3"}`; bodies["POST /api/users/2"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/users/2"])
+	}
+
+	if u, err = us.PostDisable(2); err != nil || !u.IsDisabled {
+		t.Fatalf("PostDisable is bad, have: %+v, %v", u, err)
+	}
+	if u, err = us.DeleteDisable(2); err != nil || u.IsDisabled {
+		t.Fatalf("DeleteDisable is bad, have: %+v, %v", u, err)
+	}
+	if u, err = us.PostRegenerateApiKey(2); err != nil || u.ApiKey != "newkey" {
+		t.Fatalf("PostRegenerateApiKey is bad, have: %+v, %v", u, err)
+	}
+}
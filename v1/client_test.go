@@ -36,11 +36,11 @@ func TestDefaultClient(t *testing.T) {
 	}
 
 	if client.HTTPClient() != http.DefaultClient {
-		t.Fatalf("HTTPClient is bad. want: %q, have: %q", http.DefaultClient, client.HTTPClient())
+		t.Fatalf("HTTPClient is bad. want: %v, have: %v", http.DefaultClient, client.HTTPClient())
 	}
 
 	if !reflect.DeepEqual(client.DefaultOpts(), defaultOpts()) {
-		t.Fatalf("DefaultOptions is bad. want: %q, have: %q", defaultOpts, client.DefaultOpts())
+		t.Fatalf("DefaultOptions is bad. want: %v, have: %v", defaultOpts(), client.DefaultOpts())
 	}
 }
 
@@ -135,17 +135,17 @@ func TestPost(t *testing.T) {
 			}
 			var postJson postOpt
 			if err := json.Unmarshal(buf, &postJson); err != nil {
-				t.Fatalf("Failed Json Decode have: %q err: %v", postJson, err)
+				t.Fatalf("Failed Json Decode have: %v err: %v", postJson, err)
 			}
 			if postJson == *new(postOpt) {
-				t.Fatalf("Length of postJson was 0 have: %q err: %v", postJson, err)
+				t.Fatalf("Length of postJson was 0 have: %v err: %v", postJson, err)
 			}
 			if postJson.Name != "abc" {
-				http.Error(w, fmt.Sprintf("Failed send data have: %q %q", r.Body, postJson), http.StatusInternalServerError)
+				http.Error(w, fmt.Sprintf("Failed send data have: %v %v", r.Body, postJson), http.StatusInternalServerError)
 				return
 			}
 			if !postJson.Opts.Opt1 {
-				http.Error(w, fmt.Sprintf("Failed send child data have: %q %q", r.Body, postJson), http.StatusInternalServerError)
+				http.Error(w, fmt.Sprintf("Failed send child data have: %v %v", r.Body, postJson), http.StatusInternalServerError)
 				return
 			}
 			w.Header().Set("Content-Type", "application/json")
@@ -321,10 +321,10 @@ func TestRequest(t *testing.T) {
 	}
 	var postJson postOpt
 	if err := json.Unmarshal(buf, &postJson); err != nil {
-		t.Fatalf("Failed Json Decode have: %q err: %v", postJson, err)
+		t.Fatalf("Failed Json Decode have: %v err: %v", postJson, err)
 	}
 	if postJson.Name != "abc" {
-		t.Fatalf("Failed prepare data have: %q %q", req.Body, postJson)
+		t.Fatalf("Failed prepare data have: %v %v", req.Body, postJson)
 	}
 }
 
@@ -374,7 +374,7 @@ func TestOriginalClient(t *testing.T) {
 	hc := client.HTTPClient()
 	nc := &http.Client{}
 	if !reflect.DeepEqual(hc, nc) {
-		t.Fatalf("HTTPClient is bad. want: %q, have: %q", nc, hc)
+		t.Fatalf("HTTPClient is bad. want: %v, have: %v", nc, hc)
 	}
 
 	if !reflect.DeepEqual(client.DefaultOpts(), defaultOpts()) {
@@ -468,17 +468,17 @@ func TestPostInter(t *testing.T) {
 			}
 			var postJson postOpt
 			if err := json.Unmarshal(buf, &postJson); err != nil {
-				t.Fatalf("Failed Json Decode have: %q err: %v", postJson, err)
+				t.Fatalf("Failed Json Decode have: %v err: %v", postJson, err)
 			}
 			if postJson == *new(postOpt) {
-				t.Fatalf("Length of postJson was 0 have: %q err: %v", postJson, err)
+				t.Fatalf("Length of postJson was 0 have: %v err: %v", postJson, err)
 			}
 			if postJson.Name != "abc" {
-				http.Error(w, fmt.Sprintf("Failed send data have: %q %q", r.Body, postJson), http.StatusInternalServerError)
+				http.Error(w, fmt.Sprintf("Failed send data have: %v %v", r.Body, postJson), http.StatusInternalServerError)
 				return
 			}
 			if !postJson.Opts.Opt1 {
-				http.Error(w, fmt.Sprintf("Failed send child data have: %q %q", r.Body, postJson), http.StatusInternalServerError)
+				http.Error(w, fmt.Sprintf("Failed send child data have: %v %v", r.Body, postJson), http.StatusInternalServerError)
 				return
 			}
 			w.Header().Set("Content-Type", "application/json")
@@ -672,10 +672,10 @@ func TestRequestInter(t *testing.T) {
 	}
 	var postJson postOpt
 	if err := json.Unmarshal(buf, &postJson); err != nil {
-		t.Fatalf("Failed Json Decode have: %q err: %v", postJson, err)
+		t.Fatalf("Failed Json Decode have: %v err: %v", postJson, err)
 	}
 	if postJson.Name != "abc" {
-		t.Fatalf("Failed prepare data have: %q %q", req.Body, postJson)
+		t.Fatalf("Failed prepare data have: %v %v", req.Body, postJson)
 	}
 	if !reflect.DeepEqual(client.DefaultOpts(), defaultOpts()) {
 		t.Fatalf("DefaultOpts is bad. want: %q, have: %q", defaultOpts(), client.DefaultOpts())
@@ -309,7 +309,7 @@ func init() {
 					}
 				}
 				w.Header().Set("Content-Type", "application/json")
-				fmt.Fprintf(w, rs)
+				fmt.Fprint(w, rs)
 			})
 	}
 	tgs = httptest.NewServer(mux)
@@ -393,10 +393,10 @@ func TestGetMy(t *testing.T) {
 		t.Error(err)
 	}
 	if responsed.PageSize != pageSize {
-		t.Fatalf("PageSize was not match,\n want: %q,\n have: %q\n", pageSize, responsed.PageSize)
+		t.Fatalf("PageSize was not match,\n want: %v,\n have: %v\n", pageSize, responsed.PageSize)
 	}
 	if responsed.Page != page {
-		t.Fatalf("Page was not match,\n want: %q,\n have: %q\n", page, responsed.Page)
+		t.Fatalf("Page was not match,\n want: %v,\n have: %v\n", page, responsed.Page)
 	}
 }
 
@@ -422,7 +422,7 @@ func TestPostQuery(t *testing.T) {
 		t.Error(err)
 	}
 	if responsed.DataSourceId != dataSourceId {
-		t.Fatalf("DataSourceId is not match,\n want: %q,\n have: %q\n", dataSourceId, responsed.DataSourceId)
+		t.Fatalf("DataSourceId is not match,\n want: %v,\n have: %v\n", dataSourceId, responsed.DataSourceId)
 	}
 	if responsed.Query != query {
 		t.Fatalf("Query is not match,\n want: %q,\n have: %q\n", query, responsed.Query)
@@ -448,10 +448,10 @@ func TestGetQuery(t *testing.T) {
 		t.Error(err)
 	}
 	if responsed.PageSize != pageSize {
-		t.Fatalf("PageSize was not match,\n want: %q,\n have: %q\n", pageSize, responsed.PageSize)
+		t.Fatalf("PageSize was not match,\n want: %v,\n have: %v\n", pageSize, responsed.PageSize)
 	}
 	if responsed.Page != page {
-		t.Fatalf("Page was not match,\n want: %q,\n have: %q\n", page, responsed.Page)
+		t.Fatalf("Page was not match,\n want: %v,\n have: %v\n", page, responsed.Page)
 	}
 }
 
@@ -470,10 +470,10 @@ func TestPostRefresh(t *testing.T) {
 		t.Error(err)
 	}
 	if job.Job.Status != 2 {
-		t.Fatalf("Job status is not match,\n want: %q,\n have: %q\n", 2, job.Job.Status)
+		t.Fatalf("Job status is not match,\n want: %v,\n have: %v\n", 2, job.Job.Status)
 	}
 	if job.Job.QueryResultId != 0 {
-		t.Fatalf("Query result id is not match,\n want: %q,\n have: %q\n", "", job.Job.QueryResultId)
+		t.Fatalf("Query result id is not match,\n want: %v,\n have: %v\n", "", job.Job.QueryResultId)
 	}
 }
 
@@ -494,7 +494,7 @@ func TestPostFork(t *testing.T) {
 		t.Error(err)
 	}
 	if responsed.Id == queryId {
-		t.Fatalf("Query id is not updated,\n want: != %q,\n have: %q\n", queryId, responsed.Id)
+		t.Fatalf("Query id is not updated,\n want: != %v,\n have: %v\n", queryId, responsed.Id)
 	}
 	if responsed.Name != forkedName {
 		t.Fatalf("Name is not match,\n want: %q,\n have: %q\n", forkedName, responsed.Name)
@@ -524,7 +524,7 @@ func TestPostQueryId(t *testing.T) {
 		t.Error(err)
 	}
 	if responsed.Id != queryId {
-		t.Fatalf("Query id is not match,\n want: %q,\n have: %q\n", queryId, responsed.Id)
+		t.Fatalf("Query id is not match,\n want: %v,\n have: %v\n", queryId, responsed.Id)
 	}
 	if responsed.Query != query {
 		t.Fatalf("Query is not match,\n want: %q,\n have: %q\n", query, responsed.Query)
@@ -567,7 +567,7 @@ func TestGetQueryId(t *testing.T) {
 		t.Fatalf("Format is not json,\n err: %q,\n have: %q\n", err, buf)
 	}
 	if responsed.Id != queryId {
-		t.Fatalf("Query id is not match,\n want: %q,\n have: %q\n", queryId, responsed.Id)
+		t.Fatalf("Query id is not match,\n want: %v,\n have: %v\n", queryId, responsed.Id)
 	}
 	if responsed.Name != name {
 		t.Fatalf("Query name is not match,\n want: %q,\n have: %q\n", name, responsed.Name)
@@ -592,10 +592,10 @@ func TestPostQueryResult(t *testing.T) {
 		t.Error(err)
 	}
 	if respJob.Job.Status != 2 {
-		t.Fatalf("Job status is error,\n want: %q,\n have: %q\n", 2, respJob.Job.Status)
+		t.Fatalf("Job status is error,\n want: %v,\n have: %v\n", 2, respJob.Job.Status)
 	}
 	if respJob.Job.QueryResultId != 0 {
-		t.Fatalf("QueryResultId is not null,\n want: %q,\n have: %q\n", "null", respJob.Job.QueryResultId)
+		t.Fatalf("QueryResultId is not null,\n want: %v,\n have: %v\n", "null", respJob.Job.QueryResultId)
 	}
 	if respJob.Job.Error != "" {
 		t.Fatalf("Error is not empty,\n want: %q,\n have: %q\n", "", respJob.Job.Error)
@@ -619,10 +619,10 @@ func TestGetResultsById(t *testing.T) {
 		t.Fatalf("Format is not json,\n err: %q,\n have: %q\n", err, buf)
 	}
 	if results.QueryResult.Id != queryResultId {
-		t.Fatalf("Query result id is not match,\n want: %q,\n have: %q\n", queryResultId, results.QueryResult.Id)
+		t.Fatalf("Query result id is not match,\n want: %v,\n have: %v\n", queryResultId, results.QueryResult.Id)
 	}
 	if len(results.QueryResult.Data.Rows) == 0 {
-		t.Fatalf("Rows num is bad,\n want: %q,\n have: %q\n", 2, len(results.QueryResult.Data.Rows))
+		t.Fatalf("Rows num is bad,\n want: %v,\n have: %v\n", 2, len(results.QueryResult.Data.Rows))
 	}
 }
 
@@ -642,7 +642,7 @@ func TestGetResultsByQueryId(t *testing.T) {
 		t.Fatalf("Format is not json,\n err: %q,\n hav	e: %q\n", err, buf)
 	}
 	if len(results.QueryResult.Data.Rows) == 0 {
-		t.Fatalf("Rows num is bad,\n want: %q,\n have: %q\n", 2, len(results.QueryResult.Data.Rows))
+		t.Fatalf("Rows num is bad,\n want: %v,\n have: %v\n", 2, len(results.QueryResult.Data.Rows))
 	}
 }
 
@@ -661,10 +661,10 @@ func TestGetQueryResults(t *testing.T) {
 		t.Fatalf("Format is not json,\n err: %q,\n have: %q\n", err, buf)
 	}
 	if results.QueryResult.Id != queryResultId {
-		t.Fatalf("Query result id is not match,\n want: %q,\n have: %q\n", queryResultId, results.QueryResult.Id)
+		t.Fatalf("Query result id is not match,\n want: %v,\n have: %v\n", queryResultId, results.QueryResult.Id)
 	}
 	if len(results.QueryResult.Data.Rows) == 0 {
-		t.Fatalf("Rows num is bad,\n want: %q,\n have: %q\n", 2, len(results.QueryResult.Data.Rows))
+		t.Fatalf("Rows num is bad,\n want: %v,\n have: %v\n", 2, len(results.QueryResult.Data.Rows))
 	}
 }
 
@@ -0,0 +1,225 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// Redash visualization types, see Visualization.Type.
+const (
+	VisualizationTable   = "TABLE"
+	VisualizationChart   = "CHART"
+	VisualizationCounter = "COUNTER"
+	VisualizationPivot   = "PIVOT"
+	VisualizationMap     = "MAP"
+)
+
+// Chart series types, see ChartOptions.GlobalSeriesType.
+const (
+	ChartLine    = "line"
+	ChartColumn  = "column"
+	ChartArea    = "area"
+	ChartPie     = "pie"
+	ChartScatter = "scatter"
+)
+
+// Default Visualizations
+var Visualizations = &VisualizationsS{DefaultClient}
+
+// interface of make Visualizations endpoint.
+type Visualizationser interface {
+	Visualizations(string) string
+}
+
+// Default struct for visualizations.
+type VisualizationsS struct {
+	Client Interface
+}
+
+// Default implement of Visualizations.
+func (vs VisualizationsS) Visualizations(s string) (rs string) {
+	return "/api/visualizations/" + s
+}
+
+// Wrap Redash visualization.
+type Visualization struct {
+	Id          int    `json:"id"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Options depends on Type, decode it by DecodeOptions.
+	Options   json.RawMessage `json:"options"`
+	UpdatedAt string          `json:"updated_at"`
+	CreatedAt string          `json:"created_at"`
+}
+
+// DecodeOptions decode Options into v, like *ChartOptions for CHART.
+func (v Visualization) DecodeOptions(options interface{}) error {
+	if len(v.Options) == 0 {
+		return nil
+	}
+	return json.Unmarshal(v.Options, options)
+}
+
+// Wrap Redash new visualization. It is also used to update, then
+// QueryId is ignored and zero fields are not updated.
+type NewVisualization struct {
+	QueryId     int    `json:"query_id,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Options is one of *Options struct of Type, or any json value.
+	Options interface{} `json:"options,omitempty"`
+}
+
+// Wrap Redash table visualization options.
+type TableOptions struct {
+	ItemsPerPage int           `json:"itemsPerPage,omitempty"`
+	Columns      []TableColumn `json:"columns,omitempty"`
+}
+
+// Wrap Redash table column options.
+type TableColumn struct {
+	Name         string `json:"name"`
+	Title        string `json:"title,omitempty"`
+	DisplayAs    string `json:"displayAs,omitempty"`
+	AlignContent string `json:"alignContent,omitempty"`
+	Visible      *bool  `json:"visible,omitempty"`
+	Order        int    `json:"order"`
+}
+
+// Wrap Redash chart visualization options, for line, bar and so on.
+type ChartOptions struct {
+	GlobalSeriesType string `json:"globalSeriesType"`
+	// ColumnMapping maps column name to "x", "y", "series" or "yError".
+	ColumnMapping  map[string]string             `json:"columnMapping"`
+	XAxis          ChartAxis                     `json:"xAxis"`
+	YAxis          []ChartAxis                   `json:"yAxis"`
+	Series         ChartSeries                   `json:"series"`
+	SeriesOptions  map[string]ChartSeriesOptions `json:"seriesOptions,omitempty"`
+	Legend         *ChartLegend                  `json:"legend,omitempty"`
+	ShowDataLabels bool                          `json:"showDataLabels"`
+}
+
+// Wrap Redash chart axis options.
+type ChartAxis struct {
+	// Type is "-"(auto), "category", "linear", "logarithmic" or "datetime".
+	Type     string           `json:"type"`
+	Labels   *ChartAxisLabels `json:"labels,omitempty"`
+	Title    *ChartAxisTitle  `json:"title,omitempty"`
+	Opposite bool             `json:"opposite,omitempty"`
+}
+
+// Wrap Redash chart axis labels options.
+type ChartAxisLabels struct {
+	Enabled bool `json:"enabled"`
+}
+
+// Wrap Redash chart axis title options.
+type ChartAxisTitle struct {
+	Text string `json:"text"`
+}
+
+// Wrap Redash chart series options.
+type ChartSeries struct {
+	// Stacking is nil or "stack".
+	Stacking *string `json:"stacking"`
+}
+
+// Wrap Redash chart options of each series.
+type ChartSeriesOptions struct {
+	Type   string `json:"type,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Color  string `json:"color,omitempty"`
+	YAxis  int    `json:"yAxis"`
+	ZIndex int    `json:"zIndex"`
+	Index  int    `json:"index"`
+}
+
+// Wrap Redash chart legend options.
+type ChartLegend struct {
+	Enabled bool `json:"enabled"`
+}
+
+// Wrap Redash counter visualization options.
+type CounterOptions struct {
+	CounterColName  string `json:"counterColName"`
+	RowNumber       int    `json:"rowNumber"`
+	TargetColName   string `json:"targetColName,omitempty"`
+	TargetRowNumber int    `json:"targetRowNumber,omitempty"`
+	CountRow        bool   `json:"countRow,omitempty"`
+	StringDecimal   int    `json:"stringDecimal,omitempty"`
+	StringDecChar   string `json:"stringDecChar,omitempty"`
+	StringThouSep   string `json:"stringThouSep,omitempty"`
+	StringPrefix    string `json:"stringPrefix,omitempty"`
+	StringSuffix    string `json:"stringSuffix,omitempty"`
+}
+
+// Wrap Redash pivot table visualization options.
+type PivotOptions struct {
+	Rows           []string       `json:"rows"`
+	Cols           []string       `json:"cols"`
+	Vals           []string       `json:"vals"`
+	AggregatorName string         `json:"aggregatorName,omitempty"`
+	RendererName   string         `json:"rendererName,omitempty"`
+	Controls       *PivotControls `json:"controls,omitempty"`
+}
+
+// Wrap Redash pivot table controls options.
+type PivotControls struct {
+	Enabled bool `json:"enabled"`
+}
+
+// Wrap Redash map visualization options.
+type MapOptions struct {
+	LatColName     string `json:"latColName"`
+	LonColName     string `json:"lonColName"`
+	Classify       string `json:"classify,omitempty"`
+	ClusterMarkers bool   `json:"clusterMarkers"`
+	MapTileUrl     string `json:"mapTileUrl,omitempty"`
+}
+
+// Wrap Redash api POST visualizations.
+func (vs VisualizationsS) PostVisualization(newVisualization NewVisualization) (v *Visualization, err error) {
+	return vs.PostVisualizationContext(context.Background(), newVisualization)
+}
+
+// PostVisualizationContext is PostVisualization with context.
+func (vs VisualizationsS) PostVisualizationContext(ctx context.Context, newVisualization NewVisualization) (v *Visualization, err error) {
+	v = &Visualization{}
+	if err = doJSON(ctx, vs.Client, http.MethodPost, vs.Visualizations(""), nil, newVisualization, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Wrap Redash api POST visualizations/${visualization id}.
+func (vs VisualizationsS) PostVisualizationId(visualizationId int, update NewVisualization) (v *Visualization, err error) {
+	return vs.PostVisualizationIdContext(context.Background(), visualizationId, update)
+}
+
+// PostVisualizationIdContext is PostVisualizationId with context.
+func (vs VisualizationsS) PostVisualizationIdContext(ctx context.Context, visualizationId int, update NewVisualization) (v *Visualization, err error) {
+	update.QueryId = 0
+	v = &Visualization{}
+	if err = doJSON(ctx, vs.Client, http.MethodPost, vs.Visualizations(strconv.Itoa(visualizationId)), nil, update, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Wrap Redash api DELETE visualizations/${visualization id}.
+func (vs VisualizationsS) DeleteVisualization(visualizationId int) (err error) {
+	return vs.DeleteVisualizationContext(context.Background(), visualizationId)
+}
+
+// DeleteVisualizationContext is DeleteVisualization with context.
+func (vs VisualizationsS) DeleteVisualizationContext(ctx context.Context, visualizationId int) (err error) {
+	return doJSON(ctx, vs.Client, http.MethodDelete, vs.Visualizations(strconv.Itoa(visualizationId)), nil, nil, nil)
+}
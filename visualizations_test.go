@@ -0,0 +1,93 @@
+package redash
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const visualizationResp = `{
+  "id": 2,
+  "type": "CHART",
+  "name": "Chart",
+  "description": "",
+  "options": {
+    "globalSeriesType": "column",
+    "columnMapping": {"day": "x", "count": "y"},
+    "xAxis": {"type": "datetime", "labels": {"enabled": true}},
+    "yAxis": [{"type": "linear"}],
+    "series": {"stacking": null},
+    "showDataLabels": false
+  },
+  "updated_at": "2017-07-16T10:52:26.541613+00:00",
+  "created_at": "2017-07-16T10:52:26.541613+00:00"
+}`
+
+func TestVisualizations(t *testing.T) {
+
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"POST /api/visualizations":     {body: visualizationResp},
+		"POST /api/visualizations/2":   {body: visualizationResp},
+		"DELETE /api/visualizations/2": {body: `null`},
+	})
+	vs := VisualizationsS{client}
+
+	v, err := vs.PostVisualization(NewVisualization{
+		QueryId: 1,
+		Type:    VisualizationChart,
+		Name:    "Chart",
+		Options: &ChartOptions{
+			GlobalSeriesType: ChartColumn,
+			ColumnMapping:    map[string]string{"day": "x", "count": "y"},
+			XAxis:            ChartAxis{Type: "datetime", Labels: &ChartAxisLabels{Enabled: true}},
+			YAxis:            []ChartAxis{{Type: "linear"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := bodies["POST /api/visualizations"]
+	for _, want := range []string{`"query_id":1`, `"type":"CHART"`, `"globalSeriesType":"column"`, `"series":{"stacking":null}`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, body)
+		}
+	}
+
+	var options ChartOptions
+	if err = v.DecodeOptions(&options); err != nil {
+		t.Fatal(err)
+	}
+	if options.GlobalSeriesType != ChartColumn || options.ColumnMapping["count"] != "y" || options.XAxis.Type != "datetime" {
+		t.Fatalf("Options is bad, have: %+v", options)
+	}
+
+	if _, err = vs.PostVisualizationId(2, NewVisualization{QueryId: 1, Name: "Counter", Options: &CounterOptions{CounterColName: "count"}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"Counter","options":{"counterColName":"count","rowNumber":0}}`; bodies["POST /api/visualizations/2"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/visualizations/2"])
+	}
+
+	if err = vs.DeleteVisualization(2); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEmbeddedVisualizations(t *testing.T) {
+
+	var rq ResponseQuery
+	if err := json.Unmarshal([]byte(`{"id": 1, "visualizations": [`+visualizationResp+`]}`), &rq); err != nil {
+		t.Fatal(err)
+	}
+	if len(rq.Visualizations) != 1 || rq.Visualizations[0].Type != VisualizationChart {
+		t.Fatalf("Visualizations is bad, have: %+v", rq.Visualizations)
+	}
+
+	var d Dashboard
+	if err := json.Unmarshal([]byte(dashboardResp), &d); err != nil {
+		t.Fatal(err)
+	}
+	if v := d.Widgets[0].Visualization; v == nil || v.Type != VisualizationTable {
+		t.Fatalf("Visualization of widget is bad, have: %+v", v)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 Yutaka Nishimura. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package redash
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// DashboardGridColumns is number of columns of Redash dashboard grid.
+const DashboardGridColumns = 6
+
+// Default Widgets
+var Widgets = &WidgetsS{DefaultClient}
+
+// interface of make Widgets endpoint.
+type Widgetser interface {
+	Widgets(string) string
+}
+
+// Default struct for widgets.
+type WidgetsS struct {
+	Client Interface
+}
+
+// Default implement of Widgets.
+func (ws WidgetsS) Widgets(s string) (rs string) {
+	return "/api/widgets/" + s
+}
+
+// Wrap Redash widget of dashboard.
+type Widget struct {
+	Id          int           `json:"id"`
+	DashboardId int           `json:"dashboard_id"`
+	Width       int           `json:"width"`
+	Text        string        `json:"text"`
+	Options     WidgetOptions `json:"options"`
+	// Visualization is nil for text box widget.
+	Visualization *Visualization `json:"visualization"`
+	CreatedAt     string         `json:"created_at"`
+	UpdatedAt     string         `json:"updated_at"`
+}
+
+// Wrap Redash widget options.
+type WidgetOptions struct {
+	Position          WidgetPosition  `json:"position"`
+	IsHidden          bool            `json:"isHidden,omitempty"`
+	ParameterMappings json.RawMessage `json:"parameterMappings,omitempty"`
+}
+
+// WidgetPosition is position and size of widget on dashboard grid,
+// which has DashboardGridColumns columns.
+type WidgetPosition struct {
+	Col        int  `json:"col"`
+	Row        int  `json:"row"`
+	SizeX      int  `json:"sizeX"`
+	SizeY      int  `json:"sizeY"`
+	AutoHeight bool `json:"autoHeight"`
+	MinSizeX   int  `json:"minSizeX,omitempty"`
+	MaxSizeX   int  `json:"maxSizeX,omitempty"`
+	MinSizeY   int  `json:"minSizeY,omitempty"`
+	MaxSizeY   int  `json:"maxSizeY,omitempty"`
+}
+
+// GridLayout places widgets on dashboard grid from left to right,
+// top to bottom, so that dashboards are laid out deterministically.
+type GridLayout struct {
+	col, row, rowHeight int
+}
+
+// Place return position of next widget of size. The widget is placed
+// next to previous one, or on new row if it does not fit. sizeX is
+// limited to DashboardGridColumns.
+func (g *GridLayout) Place(sizeX, sizeY int) WidgetPosition {
+	if sizeX > DashboardGridColumns {
+		sizeX = DashboardGridColumns
+	}
+	if g.col+sizeX > DashboardGridColumns {
+		g.NewRow()
+	}
+	pos := WidgetPosition{Col: g.col, Row: g.row, SizeX: sizeX, SizeY: sizeY}
+	g.col += sizeX
+	if sizeY > g.rowHeight {
+		g.rowHeight = sizeY
+	}
+	return pos
+}
+
+// NewRow makes next widget placed on new row.
+func (g *GridLayout) NewRow() {
+	if g.col == 0 {
+		return
+	}
+	g.row += g.rowHeight
+	g.col, g.rowHeight = 0, 0
+}
+
+// Wrap Redash new widget.
+type NewWidget struct {
+	DashboardId int `json:"dashboard_id"`
+	// VisualizationId is nil for text box widget.
+	VisualizationId *int          `json:"visualization_id"`
+	Text            string        `json:"text"`
+	Width           int           `json:"width"`
+	Options         WidgetOptions `json:"options"`
+}
+
+// NewVisualizationWidget make NewWidget of visualization at position.
+func NewVisualizationWidget(dashboardId, visualizationId int, position WidgetPosition) NewWidget {
+	return NewWidget{
+		DashboardId:     dashboardId,
+		VisualizationId: &visualizationId,
+		Width:           1,
+		Options:         WidgetOptions{Position: position},
+	}
+}
+
+// NewTextWidget make NewWidget of text box at position.
+func NewTextWidget(dashboardId int, text string, position WidgetPosition) NewWidget {
+	return NewWidget{
+		DashboardId: dashboardId,
+		Text:        text,
+		Width:       1,
+		Options:     WidgetOptions{Position: position},
+	}
+}
+
+// Wrap Redash widget update. Redash always updates Text, and updates
+// Options only if it is not nil.
+type WidgetUpdate struct {
+	Text    string         `json:"text"`
+	Options *WidgetOptions `json:"options,omitempty"`
+}
+
+// Wrap Redash api POST widgets.
+func (ws WidgetsS) PostWidget(newWidget NewWidget) (w *Widget, err error) {
+	return ws.PostWidgetContext(context.Background(), newWidget)
+}
+
+// PostWidgetContext is PostWidget with context.
+func (ws WidgetsS) PostWidgetContext(ctx context.Context, newWidget NewWidget) (w *Widget, err error) {
+	w = &Widget{}
+	if err = doJSON(ctx, ws.Client, http.MethodPost, ws.Widgets(""), nil, newWidget, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Wrap Redash api POST widgets/${widget id}.
+func (ws WidgetsS) PostWidgetId(widgetId int, update WidgetUpdate) (w *Widget, err error) {
+	return ws.PostWidgetIdContext(context.Background(), widgetId, update)
+}
+
+// PostWidgetIdContext is PostWidgetId with context.
+func (ws WidgetsS) PostWidgetIdContext(ctx context.Context, widgetId int, update WidgetUpdate) (w *Widget, err error) {
+	w = &Widget{}
+	if err = doJSON(ctx, ws.Client, http.MethodPost, ws.Widgets(strconv.Itoa(widgetId)), nil, update, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// PostPosition move and resize widget to position, keeping its text
+// and other options.
+func (ws WidgetsS) PostPosition(widget Widget, position WidgetPosition) (w *Widget, err error) {
+	return ws.PostPositionContext(context.Background(), widget, position)
+}
+
+// PostPositionContext is PostPosition with context.
+func (ws WidgetsS) PostPositionContext(ctx context.Context, widget Widget, position WidgetPosition) (w *Widget, err error) {
+	options := widget.Options
+	options.Position = position
+	return ws.PostWidgetIdContext(ctx, widget.Id, WidgetUpdate{Text: widget.Text, Options: &options})
+}
+
+// PostText change text of text box widget.
+func (ws WidgetsS) PostText(widgetId int, text string) (w *Widget, err error) {
+	return ws.PostTextContext(context.Background(), widgetId, text)
+}
+
+// PostTextContext is PostText with context.
+func (ws WidgetsS) PostTextContext(ctx context.Context, widgetId int, text string) (w *Widget, err error) {
+	return ws.PostWidgetIdContext(ctx, widgetId, WidgetUpdate{Text: text})
+}
+
+// Wrap Redash api DELETE widgets/${widget id}.
+func (ws WidgetsS) DeleteWidget(widgetId int) (err error) {
+	return ws.DeleteWidgetContext(context.Background(), widgetId)
+}
+
+// DeleteWidgetContext is DeleteWidget with context.
+func (ws WidgetsS) DeleteWidgetContext(ctx context.Context, widgetId int) (err error) {
+	return doJSON(ctx, ws.Client, http.MethodDelete, ws.Widgets(strconv.Itoa(widgetId)), nil, nil, nil)
+}
@@ -0,0 +1,90 @@
+package redash
+
+import (
+	"reflect"
+	"testing"
+)
+
+const widgetResp = `{
+  "id": 3,
+  "dashboard_id": 1,
+  "width": 1,
+  "text": "",
+  "options": {
+    "position": {"col": 0, "row": 0, "sizeX": 3, "sizeY": 8, "autoHeight": false},
+    "isHidden": false
+  },
+  "visualization": {"id": 2, "type": "CHART", "name": "Chart"},
+  "updated_at": "2017-07-16T10:52:26.541613+00:00",
+  "created_at": "2017-07-16T10:52:26.541613+00:00"
+}`
+
+func TestGridLayout(t *testing.T) {
+
+	var g GridLayout
+	haves := []WidgetPosition{
+		g.Place(3, 8),
+		g.Place(3, 5),
+		g.Place(2, 4),
+		g.Place(8, 2),
+	}
+	g.NewRow()
+	haves = append(haves, g.Place(1, 1))
+	wants := []WidgetPosition{
+		{Col: 0, Row: 0, SizeX: 3, SizeY: 8},
+		{Col: 3, Row: 0, SizeX: 3, SizeY: 5},
+		{Col: 0, Row: 8, SizeX: 2, SizeY: 4},
+		{Col: 0, Row: 12, SizeX: 6, SizeY: 2},
+		{Col: 0, Row: 14, SizeX: 1, SizeY: 1},
+	}
+	if !reflect.DeepEqual(haves, wants) {
+		t.Fatalf("Positions are not match,\n want: %v,\n have: %v\n", wants, haves)
+	}
+}
+
+func TestWidgets(t *testing.T) {
+
+	client, bodies := newMockServer(t, map[string]mockRoute{
+		"POST /api/widgets":     {body: widgetResp},
+		"POST /api/widgets/3":   {body: widgetResp},
+		"DELETE /api/widgets/3": {body: `null`},
+	})
+	ws := WidgetsS{client}
+
+	var g GridLayout
+	w, err := ws.PostWidget(NewVisualizationWidget(1, 2, g.Place(3, 8)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"dashboard_id":1,"visualization_id":2,"text":"","width":1,"options":{"position":{"col":0,"row":0,"sizeX":3,"sizeY":8,"autoHeight":false}}}`; bodies["POST /api/widgets"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/widgets"])
+	}
+	if w.Options.Position.SizeY != 8 || w.Visualization.Id != 2 {
+		t.Fatalf("Widget is bad, have: %+v", w)
+	}
+
+	if _, err = ws.PostWidget(NewTextWidget(1, "# hello", g.Place(3, 2))); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"dashboard_id":1,"visualization_id":null,"text":"# hello","width":1,"options":{"position":{"col":3,"row":0,"sizeX":3,"sizeY":2,"autoHeight":false}}}`; bodies["POST /api/widgets"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/widgets"])
+	}
+
+	if _, err = ws.PostPosition(*w, WidgetPosition{Col: 1, Row: 2, SizeX: 2, SizeY: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"text":"","options":{"position":{"col":1,"row":2,"sizeX":2,"sizeY":4,"autoHeight":false}}}`; bodies["POST /api/widgets/3"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/widgets/3"])
+	}
+
+	if _, err = ws.PostText(3, "changed"); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"text":"changed"}`; bodies["POST /api/widgets/3"] != want {
+		t.Fatalf("Body is not match,\n want: %s,\n have: %s\n", want, bodies["POST /api/widgets/3"])
+	}
+
+	if err = ws.DeleteWidget(3); err != nil {
+		t.Fatal(err)
+	}
+}